@@ -0,0 +1,299 @@
+package memio
+
+import (
+	"io"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// AlignedBuffer is a Buffer whose backing array starts on a given alignment,
+// and which keeps that alignment when it grows.
+//
+// ReadFrom and WriteTo read and write, where possible, in chunks that are
+// aligned and a multiple of the alignment in size, so that an AlignedBuffer
+// can be used with files opened with O_DIRECT.
+type AlignedBuffer struct {
+	Buffer
+	align int
+}
+
+// NewAlignedBuffer creates an AlignedBuffer with at least the given capacity,
+// whose backing array starts at a multiple of align.
+//
+// The alignment is rounded up to a power of two, and the capacity to a
+// multiple of the alignment.
+func NewAlignedBuffer(size, align int) *AlignedBuffer {
+	align = alignment(align)
+
+	return &AlignedBuffer{
+		Buffer: alignedSlice(0, size, align),
+		align:  align,
+	}
+}
+
+// NewAlignedLimitedBuffer creates a LimitedBuffer with at least the given
+// capacity, whose backing array starts at a multiple of align.
+//
+// The alignment is rounded up to a power of two, and the capacity to a
+// multiple of the alignment.
+func NewAlignedLimitedBuffer(size, align int) LimitedBuffer {
+	return alignedSlice(0, size, alignment(align))
+}
+
+// OpenAlignedMem acts like OpenMem, but ensures that the byte slice, and any
+// slice allocated when growing it, starts at a multiple of align. The data is
+// copied to a new slice if it is not already aligned.
+//
+// ReadFrom and WriteTo read and write, while the position is aligned, in chunks
+// that are a multiple of the alignment in size.
+func OpenAlignedMem(data *[]byte, align int) *ReadWriteMem {
+	align = alignment(align)
+
+	if !isAligned(*data, align) || cap(*data)%align != 0 {
+		aligned := alignedSlice(len(*data), cap(*data), align)
+
+		copy(aligned, *data)
+
+		*data = aligned
+	}
+
+	return &ReadWriteMem{WriteMem{data: data, align: align}}
+}
+
+// Write satisfies the io.Writer interface.
+func (a *AlignedBuffer) Write(p []byte) (int, error) {
+	a.grow(len(p))
+
+	return a.Buffer.Write(p)
+}
+
+// WriteString writes a string to the buffer without casting to a byte slice.
+func (a *AlignedBuffer) WriteString(str string) (int, error) {
+	a.grow(len(str))
+
+	return a.Buffer.WriteString(str)
+}
+
+// WriteByte satisfies the io.ByteWriter interface.
+func (a *AlignedBuffer) WriteByte(b byte) error {
+	a.grow(1)
+
+	return a.Buffer.WriteByte(b)
+}
+
+// WriteRune satisfies the io.RuneWriter interface.
+func (a *AlignedBuffer) WriteRune(r rune) (int, error) {
+	n := utf8.RuneLen(r)
+	if n < 0 {
+		n = utf8.RuneLen(utf8.RuneError)
+	}
+
+	a.grow(n)
+
+	return a.Buffer.WriteRune(r)
+}
+
+// Grow grows the capacity of the buffer, if necessary, to guarantee space for
+// another n bytes, keeping its alignment. A negative n is ignored.
+func (a *AlignedBuffer) Grow(n int) {
+	if n > 0 && fits(len(a.Buffer), n) {
+		a.grow(n)
+	}
+}
+
+// AppendInt appends the string form of the integer, in the given base, as
+// with strconv.AppendInt.
+func (a *AlignedBuffer) AppendInt(i int64, base int) {
+	defer a.realign(cap(a.Buffer))
+
+	a.Buffer.AppendInt(i, base)
+}
+
+// AppendUint appends the string form of the unsigned integer, in the given
+// base, as with strconv.AppendUint.
+func (a *AlignedBuffer) AppendUint(i uint64, base int) {
+	defer a.realign(cap(a.Buffer))
+
+	a.Buffer.AppendUint(i, base)
+}
+
+// AppendFloat appends the string form of the floating-point number, as with
+// strconv.AppendFloat.
+func (a *AlignedBuffer) AppendFloat(f float64, fmt byte, prec, bitSize int) {
+	defer a.realign(cap(a.Buffer))
+
+	a.Buffer.AppendFloat(f, fmt, prec, bitSize)
+}
+
+// AppendQuote appends a double-quoted Go string literal representing str, as
+// with strconv.AppendQuote.
+func (a *AlignedBuffer) AppendQuote(str string) {
+	defer a.realign(cap(a.Buffer))
+
+	a.Buffer.AppendQuote(str)
+}
+
+// AppendBool appends "true" or "false", according to the value of b.
+func (a *AlignedBuffer) AppendBool(b bool) {
+	defer a.realign(cap(a.Buffer))
+
+	a.Buffer.AppendBool(b)
+}
+
+// AppendTime appends the time, formatted according to the given layout, as
+// with time.Time.AppendFormat.
+func (a *AlignedBuffer) AppendTime(t time.Time, layout string) {
+	defer a.realign(cap(a.Buffer))
+
+	a.Buffer.AppendTime(t, layout)
+}
+
+// Printf appends the arguments, formatted according to the format string.
+//
+// See the Printf method of LimitedBuffer for the supported verbs.
+func (a *AlignedBuffer) Printf(format string, args ...any) {
+	defer a.realign(cap(a.Buffer))
+
+	a.Buffer.Printf(format, args...)
+}
+
+// WriteAt satisfies the io.WriteAt interface.
+func (a *AlignedBuffer) WriteAt(p []byte, off int64) (int, error) {
+	if !validRange(off, int64(len(p))) {
+		return 0, opError("writeat", off, ErrInvalidOffset)
+	} else if end := int(off) + len(p); end > cap(a.Buffer) {
+		a.grow(end - len(a.Buffer))
+	}
+
+	return a.Buffer.WriteAt(p, off)
+}
+
+// ReadFrom satisfies the io.ReaderFrom interface.
+func (a *AlignedBuffer) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+
+	if m := readSize(r, len(a.Buffer)); m > 0 {
+		a.grow(m)
+	}
+
+	for {
+		if cap(a.Buffer)-len(a.Buffer) < a.align {
+			a.grow(max(len(a.Buffer), minRead))
+		}
+
+		m, err := r.Read(alignedChunk(a.Buffer[len(a.Buffer):cap(a.Buffer)], a.align))
+		a.Buffer = a.Buffer[:len(a.Buffer)+m]
+		n += int64(m)
+
+		if err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+
+			return n, err
+		}
+	}
+}
+
+// WriteTo satisfies the io.WriterTo interface.
+//
+// If the start of the buffer is no longer aligned, due to previous reads, the
+// data is first moved to an aligned slice.
+func (a *AlignedBuffer) WriteTo(w io.Writer) (int64, error) {
+	if len(a.Buffer) == 0 {
+		return 0, nil
+	}
+
+	if !isAligned(a.Buffer, a.align) {
+		buf := alignedSlice(len(a.Buffer), cap(a.Buffer), a.align)
+
+		copy(buf, a.Buffer)
+
+		a.Buffer = buf
+	}
+
+	n, err := writeAligned(w, a.Buffer, a.align)
+	a.Buffer = a.Buffer[n:]
+
+	return n, err
+}
+
+func (a *AlignedBuffer) grow(n int) {
+	if cap(a.Buffer)-len(a.Buffer) >= n {
+		return
+	}
+
+	buf := alignedSlice(len(a.Buffer), max(cap(a.Buffer)<<1, len(a.Buffer)+n), a.align)
+
+	copy(buf, a.Buffer)
+
+	a.Buffer = buf
+}
+
+// realign moves the data to an aligned backing array if an append, which grows
+// the buffer without regard to alignment, has changed its capacity from c.
+func (a *AlignedBuffer) realign(c int) {
+	if cap(a.Buffer) == c {
+		return
+	}
+
+	buf := alignedSlice(len(a.Buffer), cap(a.Buffer), a.align)
+
+	copy(buf, a.Buffer)
+
+	a.Buffer = buf
+}
+
+func alignment(align int) int {
+	a := 1
+
+	for a < align {
+		a <<= 1
+	}
+
+	return a
+}
+
+func isAligned(p []byte, align int) bool {
+	return uintptr(unsafe.Pointer(unsafe.SliceData(p)))&uintptr(align-1) == 0
+}
+
+// alignedSlice allocates a byte slice whose backing array starts at a multiple
+// of align, and whose capacity is rounded up to a multiple of align.
+func alignedSlice(length, capacity, align int) []byte {
+	if align <= 1 {
+		return make([]byte, length, capacity)
+	}
+
+	capacity = (capacity + align - 1) &^ (align - 1)
+	buf := make([]byte, capacity+align)
+	off := int(-uintptr(unsafe.Pointer(unsafe.SliceData(buf))) & uintptr(align-1))
+
+	return buf[off : off+length : off+capacity]
+}
+
+// alignedChunk shortens the slice to a multiple of align in length, if the
+// slice is aligned and at least align bytes long.
+func alignedChunk(p []byte, align int) []byte {
+	if align <= 1 || len(p) < align || !isAligned(p, align) {
+		return p
+	}
+
+	return p[:len(p)&^(align-1)]
+}
+
+// writeAligned writes the data in an aligned chunk, a multiple of align in
+// size, followed by any remaining bytes.
+func writeAligned(w io.Writer, p []byte, align int) (int64, error) {
+	chunk := alignedChunk(p, align)
+
+	n, err := w.Write(chunk)
+	if err != nil || n < len(chunk) || len(chunk) == len(p) {
+		return int64(n), err
+	}
+
+	m, err := w.Write(p[n:])
+
+	return int64(n + m), err
+}
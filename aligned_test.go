@@ -0,0 +1,155 @@
+package memio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+)
+
+type recordReads struct {
+	io.Reader
+	sizes []int
+}
+
+func (r *recordReads) Read(p []byte) (int, error) {
+	r.sizes = append(r.sizes, len(p))
+
+	return r.Reader.Read(p)
+}
+
+type recordWrites struct {
+	bytes.Buffer
+	sizes []int
+}
+
+func (r *recordWrites) Write(p []byte) (int, error) {
+	r.sizes = append(r.sizes, len(p))
+
+	return r.Buffer.Write(p)
+}
+
+func TestAlignedBuffer(t *testing.T) {
+	a := NewAlignedBuffer(100, 500)
+
+	if a.align != 512 {
+		t.Fatalf("expecting alignment 512, got %d", a.align)
+	} else if !isAligned(a.Buffer, 512) {
+		t.Fatalf("buffer not aligned")
+	} else if cap(a.Buffer) != 512 {
+		t.Fatalf("expecting capacity 512, got %d", cap(a.Buffer))
+	}
+
+	a.Write(make([]byte, 1000))
+
+	if !isAligned(a.Buffer, 512) {
+		t.Fatalf("buffer not aligned after growth")
+	}
+
+	a.Read(make([]byte, 1000))
+
+	r := &recordReads{Reader: struct{ io.Reader }{bytes.NewReader(make([]byte, 3000))}}
+
+	if n, err := a.ReadFrom(r); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if n != 3000 {
+		t.Fatalf("expecting to read 3000 bytes, read %d", n)
+	}
+
+	w := new(recordWrites)
+
+	if n, err := a.WriteTo(w); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if n != 3000 {
+		t.Fatalf("expecting to write 3000 bytes, wrote %d", n)
+	}
+
+	for _, s := range w.sizes[:len(w.sizes)-1] {
+		if s%512 != 0 {
+			t.Errorf("expecting write sizes to be a multiple of 512, got %v", w.sizes)
+
+			break
+		}
+	}
+}
+
+func TestAlignedBufferGrow(t *testing.T) {
+	a := NewAlignedBuffer(1, 64)
+
+	if a.Grow(1000); !isAligned(a.Buffer, 64) || cap(a.Buffer) < 1000 {
+		t.Fatalf("expecting aligned buffer with capacity of at least 1000, got %d", cap(a.Buffer))
+	}
+
+	a.Write(make([]byte, cap(a.Buffer)))
+
+	if n, err := a.WriteRune(-1); n != 3 || err != nil {
+		t.Fatalf("expecting to write 3 bytes with nil error, wrote %d with %v", n, err)
+	} else if !isAligned(a.Buffer, 64) {
+		t.Fatalf("buffer not aligned after growth")
+	}
+
+	a = NewAlignedBuffer(1, 4096)
+
+	for n, fn := range [...]func(){
+		func() { a.AppendInt(-123, 10) },
+		func() { a.AppendUint(123, 2) },
+		func() { a.AppendFloat(1.5, 'f', -1, 64) },
+		func() { a.AppendQuote("abc") },
+		func() { a.AppendBool(true) },
+		func() { a.AppendTime(time.Unix(0, 0).UTC(), time.RFC3339) },
+		func() { a.Printf("%d %s", 1, "a") },
+	} {
+		a.Write(make([]byte, cap(a.Buffer)-len(a.Buffer)))
+
+		if fn(); !isAligned(a.Buffer, 4096) || cap(a.Buffer)%4096 != 0 {
+			t.Fatalf("test %d: buffer not aligned after growth", n+1)
+		}
+	}
+
+	if _, err := a.WriteAt([]byte("a"), -1); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if _, err = a.WriteAt([]byte("ab"), math.MaxInt64); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	}
+}
+
+func TestAlignedMem(t *testing.T) {
+	data := []byte("Hello")
+	rw := OpenAlignedMem(&data, 4096)
+
+	if !isAligned(data, 4096) {
+		t.Fatalf("data not aligned")
+	} else if string(data) != "Hello" {
+		t.Fatalf("expecting %q, got %q", "Hello", data)
+	}
+
+	rw.Truncate(0)
+
+	r := &recordReads{Reader: bytes.NewReader(make([]byte, 10000))}
+
+	if n, err := rw.ReadFrom(r); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if n != 10000 {
+		t.Fatalf("expecting to read 10000 bytes, read %d", n)
+	} else if !isAligned(data, 4096) {
+		t.Fatalf("data not aligned after growth")
+	}
+
+	for _, s := range r.sizes[:len(r.sizes)-1] {
+		if s%4096 != 0 {
+			t.Errorf("expecting read sizes to be a multiple of 4096, got %v", r.sizes)
+
+			break
+		}
+	}
+
+	lb := NewAlignedLimitedBuffer(1, 4096)
+
+	if !isAligned(lb, 4096) {
+		t.Errorf("limited buffer not aligned")
+	} else if cap(lb) != 4096 {
+		t.Errorf("expecting capacity 4096, got %d", cap(lb))
+	}
+}
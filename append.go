@@ -0,0 +1,355 @@
+package memio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// AppendInt appends the string form of the integer, in the given base, as
+// with strconv.AppendInt.
+func (s *Buffer) AppendInt(i int64, base int) {
+	*s = strconv.AppendInt(*s, i, base)
+}
+
+// AppendUint appends the string form of the unsigned integer, in the given
+// base, as with strconv.AppendUint.
+func (s *Buffer) AppendUint(i uint64, base int) {
+	*s = strconv.AppendUint(*s, i, base)
+}
+
+// AppendFloat appends the string form of the floating-point number, as with
+// strconv.AppendFloat.
+func (s *Buffer) AppendFloat(f float64, fmt byte, prec, bitSize int) {
+	*s = strconv.AppendFloat(*s, f, fmt, prec, bitSize)
+}
+
+// AppendQuote appends a double-quoted Go string literal representing str, as
+// with strconv.AppendQuote.
+func (s *Buffer) AppendQuote(str string) {
+	*s = strconv.AppendQuote(*s, str)
+}
+
+// AppendBool appends "true" or "false", according to the value of b.
+func (s *Buffer) AppendBool(b bool) {
+	*s = strconv.AppendBool(*s, b)
+}
+
+// AppendTime appends the time, formatted according to the given layout, as
+// with time.Time.AppendFormat.
+func (s *Buffer) AppendTime(t time.Time, layout string) {
+	*s = t.AppendFormat(*s, layout)
+}
+
+// Printf appends the arguments, formatted according to the format string.
+//
+// See the Printf method of LimitedBuffer for the supported verbs.
+func (s *Buffer) Printf(format string, args ...any) {
+	*s = appendf(*s, format, args)
+}
+
+// AppendInt appends the string form of the integer, in the given base, as
+// with strconv.AppendInt.
+//
+// As with all of the Append methods, if the result does not fit within the
+// capacity of the buffer, nothing is appended and ErrTooLarge is returned.
+func (s *LimitedBuffer) AppendInt(i int64, base int) error {
+	return s.appended(strconv.AppendInt(s.spare(), i, base))
+}
+
+// AppendUint appends the string form of the unsigned integer, in the given
+// base, as with strconv.AppendUint.
+func (s *LimitedBuffer) AppendUint(i uint64, base int) error {
+	return s.appended(strconv.AppendUint(s.spare(), i, base))
+}
+
+// AppendFloat appends the string form of the floating-point number, as with
+// strconv.AppendFloat.
+func (s *LimitedBuffer) AppendFloat(f float64, fmt byte, prec, bitSize int) error {
+	return s.appended(strconv.AppendFloat(s.spare(), f, fmt, prec, bitSize))
+}
+
+// AppendQuote appends a double-quoted Go string literal representing str, as
+// with strconv.AppendQuote.
+func (s *LimitedBuffer) AppendQuote(str string) error {
+	return s.appended(strconv.AppendQuote(s.spare(), str))
+}
+
+// AppendBool appends "true" or "false", according to the value of b.
+func (s *LimitedBuffer) AppendBool(b bool) error {
+	return s.appended(strconv.AppendBool(s.spare(), b))
+}
+
+// AppendTime appends the time, formatted according to the given layout, as
+// with time.Time.AppendFormat.
+func (s *LimitedBuffer) AppendTime(t time.Time, layout string) error {
+	return s.appended(t.AppendFormat(s.spare(), layout))
+}
+
+// Printf appends the arguments, formatted according to the format string.
+//
+// The supported verbs are:
+//
+//	%d  integers, in base 10
+//	%x  integers, in base 16, and strings and byte slices, as hex
+//	%s  strings, byte slices, errors and fmt.Stringers
+//	%q  strings, byte slices, errors and fmt.Stringers, as quoted strings
+//	%v  all of the above, as well as bools and floats
+//	%%  a literal percent sign
+//
+// Flags, widths and precisions are not supported. Unsupported verbs and
+// arguments are written as %!verb(BADARG), and missing arguments as
+// %!verb(MISSING).
+//
+// Unlike the Append methods, which do not allocate, Printf may allocate to
+// pass its arguments.
+func (s *LimitedBuffer) Printf(format string, args ...any) error {
+	return s.appended(appendf(s.spare(), format, args))
+}
+
+// spare returns the unused capacity of the buffer, with zero length, to be
+// formatted into.
+func (s *LimitedBuffer) spare() []byte {
+	return (*s)[len(*s):len(*s)]
+}
+
+// appended extends the buffer over the data formatted into its spare
+// capacity, or returns ErrTooLarge if the data did not fit, in which case it
+// was reallocated and the buffer is left unchanged.
+func (s *LimitedBuffer) appended(out []byte) error {
+	l := len(*s)
+	if len(out) > cap(*s)-l {
+		return ErrTooLarge
+	}
+
+	*s = (*s)[:l+len(out)]
+
+	return nil
+}
+
+// AppendInt writes the string form of the integer, in the given base, as with
+// strconv.AppendInt.
+func (b *WriteMem) AppendInt(i int64, base int) error {
+	var buf [64]byte
+
+	return b.appended(strconv.AppendInt(b.spare(buf[:0]), i, base))
+}
+
+// AppendUint writes the string form of the unsigned integer, in the given
+// base, as with strconv.AppendUint.
+func (b *WriteMem) AppendUint(i uint64, base int) error {
+	var buf [64]byte
+
+	return b.appended(strconv.AppendUint(b.spare(buf[:0]), i, base))
+}
+
+// AppendFloat writes the string form of the floating-point number, as with
+// strconv.AppendFloat.
+func (b *WriteMem) AppendFloat(f float64, fmt byte, prec, bitSize int) error {
+	var buf [64]byte
+
+	return b.appended(strconv.AppendFloat(b.spare(buf[:0]), f, fmt, prec, bitSize))
+}
+
+// AppendQuote writes a double-quoted Go string literal representing str, as
+// with strconv.AppendQuote.
+func (b *WriteMem) AppendQuote(str string) error {
+	var buf [64]byte
+
+	return b.appended(strconv.AppendQuote(b.spare(buf[:0]), str))
+}
+
+// AppendBool writes "true" or "false", according to the value of v.
+func (b *WriteMem) AppendBool(v bool) error {
+	var buf [64]byte
+
+	return b.appended(strconv.AppendBool(b.spare(buf[:0]), v))
+}
+
+// AppendTime writes the time, formatted according to the given layout, as
+// with time.Time.AppendFormat.
+func (b *WriteMem) AppendTime(t time.Time, layout string) error {
+	var buf [64]byte
+
+	return b.appended(t.AppendFormat(b.spare(buf[:0]), layout))
+}
+
+// Printf writes the arguments, formatted according to the format string.
+//
+// See the Printf method of LimitedBuffer for the supported verbs.
+func (b *WriteMem) Printf(format string, args ...any) error {
+	var buf [64]byte
+
+	return b.appended(appendf(b.spare(buf[:0]), format, args))
+}
+
+// spare returns a zero-length slice to be formatted into: the spare capacity
+// of the byte slice when the position is at its end, so that the data is
+// formatted in place, or else the given scratch buffer.
+func (b *WriteMem) spare(scratch []byte) []byte {
+	if b.data != nil && b.pos == len(*b.data) {
+		return (*b.data)[b.pos:b.pos]
+	}
+
+	return scratch
+}
+
+// appended writes the formatted data at the current position, extending the
+// byte slice over it if it was formatted in place.
+func (b *WriteMem) appended(out []byte) error {
+	if b.data == nil {
+		return opError("append", int64(b.pos), ErrClosed)
+	}
+
+	if b.pos == len(*b.data) && len(out) <= cap(*b.data)-b.pos {
+		*b.data = (*b.data)[:b.pos+len(out)]
+
+		b.index.update(b.pos, len(out), len(out))
+
+		b.pos += len(out)
+
+		return nil
+	}
+
+	_, err := b.Write(out)
+
+	return err
+}
+
+// appendf appends the arguments, formatted according to the format string.
+func appendf(p []byte, format string, args []any) []byte {
+	var arg int
+
+	for {
+		n := strings.IndexByte(format, '%')
+		if n < 0 {
+			return append(p, format...)
+		}
+
+		p = append(p, format[:n]...)
+		format = format[n+1:]
+
+		if format == "" {
+			return append(p, "%!(NOVERB)"...)
+		}
+
+		verb := format[0]
+		format = format[1:]
+
+		if verb == '%' {
+			p = append(p, '%')
+		} else if arg >= len(args) {
+			p = appendBadVerb(p, verb, "MISSING")
+		} else {
+			p = appendArg(p, verb, args[arg])
+			arg++
+		}
+	}
+}
+
+func appendArg(p []byte, verb byte, arg any) []byte {
+	switch v := arg.(type) {
+	case nil:
+		if verb == 'v' || verb == 's' {
+			return append(p, "<nil>"...)
+		}
+	case bool:
+		if verb == 'v' {
+			return strconv.AppendBool(p, v)
+		}
+	case int:
+		return appendInt(p, verb, int64(v))
+	case int8:
+		return appendInt(p, verb, int64(v))
+	case int16:
+		return appendInt(p, verb, int64(v))
+	case int32:
+		return appendInt(p, verb, int64(v))
+	case int64:
+		return appendInt(p, verb, v)
+	case uint:
+		return appendUint(p, verb, uint64(v))
+	case uint8:
+		return appendUint(p, verb, uint64(v))
+	case uint16:
+		return appendUint(p, verb, uint64(v))
+	case uint32:
+		return appendUint(p, verb, uint64(v))
+	case uint64:
+		return appendUint(p, verb, v)
+	case uintptr:
+		return appendUint(p, verb, uint64(v))
+	case float32:
+		if verb == 'v' {
+			return strconv.AppendFloat(p, float64(v), 'g', -1, 32)
+		}
+	case float64:
+		if verb == 'v' {
+			return strconv.AppendFloat(p, v, 'g', -1, 64)
+		}
+	case string:
+		return appendString(p, verb, v)
+	case []byte:
+		return appendString(p, verb, unsafe.String(unsafe.SliceData(v), len(v)))
+	case error:
+		if verb != 'x' {
+			return appendString(p, verb, v.Error())
+		}
+	case fmt.Stringer:
+		if verb != 'x' {
+			return appendString(p, verb, v.String())
+		}
+	}
+
+	return appendBadVerb(p, verb, "BADARG")
+}
+
+func appendInt(p []byte, verb byte, i int64) []byte {
+	switch verb {
+	case 'd', 'v':
+		return strconv.AppendInt(p, i, 10)
+	case 'x':
+		return strconv.AppendInt(p, i, 16)
+	}
+
+	return appendBadVerb(p, verb, "BADARG")
+}
+
+func appendUint(p []byte, verb byte, i uint64) []byte {
+	switch verb {
+	case 'd', 'v':
+		return strconv.AppendUint(p, i, 10)
+	case 'x':
+		return strconv.AppendUint(p, i, 16)
+	}
+
+	return appendBadVerb(p, verb, "BADARG")
+}
+
+func appendString(p []byte, verb byte, s string) []byte {
+	switch verb {
+	case 's', 'v':
+		return append(p, s...)
+	case 'q':
+		return strconv.AppendQuote(p, s)
+	case 'x':
+		const hex = "0123456789abcdef"
+
+		for n := 0; n < len(s); n++ {
+			p = append(p, hex[s[n]>>4], hex[s[n]&0xf])
+		}
+
+		return p
+	}
+
+	return appendBadVerb(p, verb, "BADARG")
+}
+
+func appendBadVerb(p []byte, verb byte, reason string) []byte {
+	p = append(p, '%', '!', verb, '(')
+	p = append(p, reason...)
+
+	return append(p, ')')
+}
@@ -0,0 +1,182 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+type stringer struct{}
+
+func (stringer) String() string {
+	return "str"
+}
+
+var (
+	argInt        = 12345
+	argUint       = uint64(67890)
+	argFloat      = 1.5
+	argString     = "hello"
+	argBytes      = []byte{1, 0xab}
+	argErr        = errors.New("bad")
+	argStr        = stringer{}
+	argNil        any
+	argTime       = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	appendFormat  = "%d %x %v %v %s %q %x %s %q %v %v 100%%"
+	appendExpects = `12345 3039 67890 1.5 hello "hello" 01ab str "str" bad <nil> 100%`
+
+	appendAllExpects = `-12ff0.25"a\n"true2020-01-02`
+)
+
+func (b *Buffer) printAll() {
+	b.Printf(appendFormat, argInt, argInt, argUint, argFloat, argString, argString, argBytes, argStr, argStr, argErr, argNil)
+}
+
+func (s *LimitedBuffer) printAll() error {
+	return s.Printf(appendFormat, argInt, argInt, argUint, argFloat, argString, argString, argBytes, argStr, argStr, argErr, argNil)
+}
+
+func (b *WriteMem) printAll() error {
+	return b.Printf(appendFormat, argInt, argInt, argUint, argFloat, argString, argString, argBytes, argStr, argStr, argErr, argNil)
+}
+
+func (b *Buffer) appendAll() {
+	b.AppendInt(-12, 10)
+	b.AppendUint(255, 16)
+	b.AppendFloat(0.25, 'f', 2, 64)
+	b.AppendQuote("a\n")
+	b.AppendBool(true)
+	b.AppendTime(argTime, time.DateOnly)
+}
+
+func (s *LimitedBuffer) appendAll() error {
+	return errors.Join(
+		s.AppendInt(-12, 10),
+		s.AppendUint(255, 16),
+		s.AppendFloat(0.25, 'f', 2, 64),
+		s.AppendQuote("a\n"),
+		s.AppendBool(true),
+		s.AppendTime(argTime, time.DateOnly),
+	)
+}
+
+func (b *WriteMem) appendAll() error {
+	return errors.Join(
+		b.AppendInt(-12, 10),
+		b.AppendUint(255, 16),
+		b.AppendFloat(0.25, 'f', 2, 64),
+		b.AppendQuote("a\n"),
+		b.AppendBool(true),
+		b.AppendTime(argTime, time.DateOnly),
+	)
+}
+
+func TestBufferAppend(t *testing.T) {
+	var b Buffer
+
+	b.appendAll()
+
+	if string(b) != appendAllExpects {
+		t.Errorf("expecting %q, got %q", appendAllExpects, b)
+	} else if n := testing.AllocsPerRun(100, func() { b = b[:0]; b.appendAll() }); n != 0 {
+		t.Errorf("expecting no allocations, got %f", n)
+	}
+
+	b = b[:0]
+
+	b.printAll()
+
+	if string(b) != appendExpects {
+		t.Errorf("expecting %q, got %q", appendExpects, b)
+	}
+
+	b = b[:0]
+
+	b.Printf("%d %s %v %x %d", "a", 1, 'a', true)
+
+	if expected := "%!d(BADARG) %!s(BADARG) 97 %!x(BADARG) %!d(MISSING)"; string(b) != expected {
+		t.Errorf("expecting %q, got %q", expected, b)
+	}
+}
+
+func TestLimitedBufferAppend(t *testing.T) {
+	b := make(LimitedBuffer, 0, 128)
+
+	if err := b.printAll(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(b) != appendExpects {
+		t.Errorf("expecting %q, got %q", appendExpects, b)
+	} else if b = b[:0]; b.appendAll() != nil {
+		t.Errorf("unexpected error")
+	} else if string(b) != appendAllExpects {
+		t.Errorf("expecting %q, got %q", appendAllExpects, b)
+	} else if n := testing.AllocsPerRun(100, func() { b = b[:0]; b.appendAll() }); n != 0 {
+		t.Errorf("expecting no allocations, got %f", n)
+	}
+
+	b = make(LimitedBuffer, 0, 6)
+
+	if err := b.AppendInt(1234, 10); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if err = b.AppendInt(567, 10); err != ErrTooLarge {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	} else if string(b) != "1234" {
+		t.Errorf("expecting %q, got %q", "1234", b)
+	} else if err = b.AppendInt(56, 10); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(b) != "123456" {
+		t.Errorf("expecting %q, got %q", "123456", b)
+	} else if b = b[:3]; b.Printf("%s", "世界") != ErrTooLarge {
+		t.Errorf("expecting ErrTooLarge")
+	} else if string(b) != "123" {
+		t.Errorf("expecting %q, got %q", "123", b)
+	} else if b = append(b[:0], "1234"...); b.AppendQuote("a") != ErrTooLarge {
+		t.Errorf("expecting ErrTooLarge")
+	} else if string(b) != "1234" {
+		t.Errorf("expecting %q, got %q", "1234", b)
+	}
+}
+
+func TestWriteMemAppend(t *testing.T) {
+	data := make([]byte, 0, 128)
+	w := Create(&data)
+
+	if err := w.printAll(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != appendExpects {
+		t.Errorf("expecting %q, got %q", appendExpects, data)
+	} else if err = w.Truncate(0); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = w.Seek(0, io.SeekStart); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if err = w.appendAll(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != appendAllExpects {
+		t.Errorf("expecting %q, got %q", appendAllExpects, data)
+	} else if n := testing.AllocsPerRun(100, func() { w.Truncate(0); w.Seek(0, io.SeekStart); w.appendAll() }); n != 0 {
+		t.Errorf("expecting no allocations at the end, got %f", n)
+	} else if n := testing.AllocsPerRun(100, func() { w.Seek(0, io.SeekStart); w.appendAll() }); n != 0 {
+		t.Errorf("expecting no allocations when overwriting, got %f", n)
+	} else if string(data) != appendAllExpects {
+		t.Errorf("expecting %q, got %q", appendAllExpects, data)
+	} else if err = w.Truncate(0); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = w.Seek(0, io.SeekStart); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if err = w.printAll(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = w.Seek(2, io.SeekStart); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if err = w.AppendBool(false); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if expected := "12false" + appendExpects[7:]; string(data) != expected {
+		t.Errorf("expecting %q, got %q", expected, data)
+	} else if w.Seek(0, io.SeekEnd); w.AppendUint(1, 10) != nil {
+		t.Errorf("unexpected error")
+	} else if expected += "1"; string(data) != expected {
+		t.Errorf("expecting %q, got %q", expected, data)
+	} else if w.Close(); !errors.Is(w.AppendInt(1, 10), ErrClosed) {
+		t.Errorf("expecting ErrClosed")
+	}
+}
@@ -0,0 +1,136 @@
+package memio
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const benchSize = 1 << 22
+
+type hiddenSize struct {
+	io.Reader
+}
+
+func benchReadFrom(b *testing.B, newReader func([]byte) io.Reader, readFrom func(io.Reader) error) {
+	data := make([]byte, benchSize)
+
+	b.SetBytes(benchSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if err := readFrom(newReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func sizedReader(data []byte) io.Reader {
+	return bytes.NewReader(data)
+}
+
+func unsizedReader(data []byte) io.Reader {
+	return hiddenSize{bytes.NewReader(data)}
+}
+
+func readFromWriteMem(r io.Reader) error {
+	var data []byte
+
+	_, err := Create(&data).ReadFrom(r)
+
+	return err
+}
+
+func readFromBuffer(r io.Reader) error {
+	var buf Buffer
+
+	_, err := buf.ReadFrom(r)
+
+	return err
+}
+
+func readFromBytesBuffer(r io.Reader) error {
+	var buf bytes.Buffer
+
+	_, err := buf.ReadFrom(r)
+
+	return err
+}
+
+func BenchmarkReadFromWriteMemSized(b *testing.B) {
+	benchReadFrom(b, sizedReader, readFromWriteMem)
+}
+
+func BenchmarkReadFromWriteMemUnsized(b *testing.B) {
+	benchReadFrom(b, unsizedReader, readFromWriteMem)
+}
+
+func BenchmarkReadFromBufferSized(b *testing.B) {
+	benchReadFrom(b, sizedReader, readFromBuffer)
+}
+
+func BenchmarkReadFromBufferUnsized(b *testing.B) {
+	benchReadFrom(b, unsizedReader, readFromBuffer)
+}
+
+func BenchmarkReadFromBytesBufferSized(b *testing.B) {
+	benchReadFrom(b, sizedReader, readFromBytesBuffer)
+}
+
+func BenchmarkReadFromBytesBufferUnsized(b *testing.B) {
+	benchReadFrom(b, unsizedReader, readFromBytesBuffer)
+}
+
+func benchFile(b *testing.B, read func(string) error) {
+	name := filepath.Join(b.TempDir(), "data")
+
+	if err := os.WriteFile(name, make([]byte, benchSize), 0o600); err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(benchSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if err := read(name); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func openAndRead(readFrom func(io.Reader) error) func(string) error {
+	return func(name string) error {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		return readFrom(f)
+	}
+}
+
+func BenchmarkFileWriteMem(b *testing.B) {
+	benchFile(b, openAndRead(readFromWriteMem))
+}
+
+func BenchmarkFileBuffer(b *testing.B) {
+	benchFile(b, openAndRead(readFromBuffer))
+}
+
+func BenchmarkFileBytesBuffer(b *testing.B) {
+	benchFile(b, openAndRead(readFromBytesBuffer))
+}
+
+func BenchmarkFileOSReadFile(b *testing.B) {
+	benchFile(b, func(name string) error {
+		_, err := os.ReadFile(name)
+
+		return err
+	})
+}
@@ -0,0 +1,190 @@
+package memio
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// BoundedBuffer is a Buffer that starts small and grows as needed, but never
+// holds more than a fixed maximum number of bytes.
+//
+// Writes beyond the maximum write as much as will fit and return
+// io.ErrShortWrite. The Append and Printf methods of the embedded Buffer are
+// not bounded.
+type BoundedBuffer struct {
+	Buffer
+	limit int
+}
+
+// NewBoundedBuffer creates a BoundedBuffer with the given initial capacity,
+// that will grow to hold at most limit bytes.
+func NewBoundedBuffer(size, limit int) *BoundedBuffer {
+	return &BoundedBuffer{
+		Buffer: make(Buffer, 0, max(min(size, limit), 0)),
+		limit:  limit,
+	}
+}
+
+// Write satisfies the io.Writer interface.
+func (b *BoundedBuffer) Write(p []byte) (int, error) {
+	return boundedWrite(b, p)
+}
+
+// WriteString writes a string to the buffer without casting to a byte slice.
+func (b *BoundedBuffer) WriteString(str string) (int, error) {
+	return boundedWrite(b, str)
+}
+
+// WriteByte satisfies the io.ByteWriter interface.
+func (b *BoundedBuffer) WriteByte(c byte) error {
+	if len(b.Buffer) >= b.limit {
+		return io.ErrShortWrite
+	}
+
+	b.grow(1)
+
+	b.Buffer = append(b.Buffer, c)
+
+	return nil
+}
+
+// WriteRune satisfies the io.RuneWriter interface.
+//
+// If the encoded rune does not fit, nothing is written and io.ErrShortWrite
+// is returned.
+func (b *BoundedBuffer) WriteRune(r rune) (int, error) {
+	n := utf8.RuneLen(r)
+	if n < 0 {
+		n = utf8.RuneLen(utf8.RuneError)
+	}
+
+	if len(b.Buffer)+n > b.limit {
+		return 0, io.ErrShortWrite
+	}
+
+	b.grow(n)
+
+	return b.Buffer.WriteRune(r)
+}
+
+// Grow grows the capacity of the buffer, if necessary, to guarantee space for
+// another n bytes, but not beyond the maximum size. A negative n is ignored.
+func (b *BoundedBuffer) Grow(n int) {
+	if n > 0 {
+		b.grow(n)
+	}
+}
+
+// WriteAt satisfies the io.WriterAt interface.
+func (b *BoundedBuffer) WriteAt(p []byte, off int64) (int, error) {
+	if !validRange(off, int64(len(p))) {
+		return 0, opError("writeat", off, ErrInvalidOffset)
+	} else if len(p) == 0 {
+		return 0, nil
+	} else if off >= int64(b.limit) {
+		return 0, io.ErrShortWrite
+	}
+
+	var err error
+
+	if left := int64(b.limit) - off; int64(len(p)) > left {
+		p = p[:left]
+		err = io.ErrShortWrite
+	}
+
+	n, _ := b.Buffer.WriteAt(p, off)
+
+	return n, err
+}
+
+// ReadFrom satisfies the io.ReaderFrom interface.
+//
+// ReadFrom reads until EOF or until the buffer holds its maximum number of
+// bytes. In the latter case, if the Reader has more data pending,
+// io.ErrShortWrite is returned.
+//
+// Checking for pending data reads, and discards, a single byte, unless the
+// Reader has a Peek method, such as with bufio.Reader or Peeker.
+func (b *BoundedBuffer) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+
+	if hint := sizeHint(r); hint > 0 {
+		b.grow(int(min(hint, maxInt-1)) + 1)
+	}
+
+	for len(b.Buffer) < b.limit {
+		if len(b.Buffer) == cap(b.Buffer) {
+			b.grow(max(len(b.Buffer), minRead))
+		}
+
+		m, err := r.Read(b.Buffer[len(b.Buffer):min(cap(b.Buffer), b.limit)])
+		b.Buffer = b.Buffer[:len(b.Buffer)+m]
+		n += int64(m)
+
+		if err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+
+			return n, err
+		}
+	}
+
+	if pending(r) {
+		return n, io.ErrShortWrite
+	}
+
+	return n, nil
+}
+
+// Limit returns the maximum number of bytes the buffer can hold.
+func (b *BoundedBuffer) Limit() int {
+	return b.limit
+}
+
+// grow ensures that there is space for n more bytes, or as many as remain
+// before the limit, doubling the capacity, but not beyond the limit, when
+// reallocation is required.
+func (b *BoundedBuffer) grow(n int) {
+	n = min(n, b.limit-len(b.Buffer))
+
+	if cap(b.Buffer)-len(b.Buffer) >= n {
+		return
+	}
+
+	buf := make(Buffer, len(b.Buffer), min(max(cap(b.Buffer)<<1, len(b.Buffer)+n), b.limit))
+
+	copy(buf, b.Buffer)
+
+	b.Buffer = buf
+}
+
+func boundedWrite[T string | []byte](b *BoundedBuffer, p T) (int, error) {
+	var err error
+
+	if left := max(b.limit-len(b.Buffer), 0); len(p) > left {
+		p = p[:left]
+		err = io.ErrShortWrite
+	}
+
+	b.grow(len(p))
+
+	b.Buffer = append(b.Buffer, p...)
+
+	return len(p), err
+}
+
+// pending reports whether the Reader has more data to read.
+func pending(r io.Reader) bool {
+	if p, ok := r.(interface{ Peek(int) ([]byte, error) }); ok {
+		buf, _ := p.Peek(1)
+
+		return len(buf) > 0
+	}
+
+	var buf [1]byte
+
+	n, _ := io.ReadFull(r, buf[:])
+
+	return n > 0
+}
@@ -0,0 +1,97 @@
+package memio
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ io.Writer     = &BoundedBuffer{}
+	_ io.ByteWriter = &BoundedBuffer{}
+	_ io.WriterAt   = &BoundedBuffer{}
+	_ io.ReaderFrom = &BoundedBuffer{}
+)
+
+func TestBoundedBufferWrite(t *testing.T) {
+	b := NewBoundedBuffer(2, 10)
+
+	if n, err := b.WriteString("abc"); n != 3 || err != nil {
+		t.Errorf("expecting to write 3 bytes with nil error, wrote %d with %v", n, err)
+	} else if cap(b.Buffer) != 4 {
+		t.Errorf("expecting capacity 4, got %d", cap(b.Buffer))
+	} else if n, err = b.Write([]byte("defgh")); n != 5 || err != nil {
+		t.Errorf("expecting to write 5 bytes with nil error, wrote %d with %v", n, err)
+	} else if cap(b.Buffer) != 8 {
+		t.Errorf("expecting capacity 8, got %d", cap(b.Buffer))
+	} else if err = b.WriteByte('i'); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if cap(b.Buffer) != 10 {
+		t.Errorf("expecting capacity 10, got %d", cap(b.Buffer))
+	} else if n, err = b.WriteString("jk"); n != 1 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 1 byte with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if err = b.WriteByte('l'); err != io.ErrShortWrite {
+		t.Errorf("expecting io.ErrShortWrite, got %v", err)
+	} else if string(b.Buffer) != "abcdefghij" {
+		t.Errorf("expecting %q, got %q", "abcdefghij", b.Buffer)
+	} else if n, err = b.WriteAt([]byte("XYZ"), 8); n != 2 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 2 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if n, err = b.WriteAt([]byte("X"), 10); n != 0 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 0 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if _, err = b.WriteAt([]byte("X"), -1); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if string(b.Buffer) != "abcdefghXY" {
+		t.Errorf("expecting %q, got %q", "abcdefghXY", b.Buffer)
+	}
+}
+
+func TestBoundedBufferReadFrom(t *testing.T) {
+	b := NewBoundedBuffer(0, 1000)
+
+	if n, err := b.ReadFrom(strings.NewReader(strings.Repeat("a", 1000))); n != 1000 || err != nil {
+		t.Errorf("expecting to read 1000 bytes with nil error, read %d with %v", n, err)
+	} else if cap(b.Buffer) != 1000 {
+		t.Errorf("expecting capacity 1000, got %d", cap(b.Buffer))
+	}
+
+	b = NewBoundedBuffer(16, 1000)
+
+	if n, err := b.ReadFrom(io.MultiReader(strings.NewReader(strings.Repeat("a", 999)))); n != 999 || err != nil {
+		t.Errorf("expecting to read 999 bytes with nil error, read %d with %v", n, err)
+	} else if cap(b.Buffer) > 1000 {
+		t.Errorf("expecting capacity no more than 1000, got %d", cap(b.Buffer))
+	}
+
+	b = NewBoundedBuffer(16, 100)
+	r := bufio.NewReader(strings.NewReader(strings.Repeat("a", 100) + "b"))
+
+	if n, err := b.ReadFrom(r); n != 100 || err != io.ErrShortWrite {
+		t.Errorf("expecting to read 100 bytes with io.ErrShortWrite, read %d with %v", n, err)
+	} else if c, _ := r.ReadByte(); c != 'b' {
+		t.Errorf("expecting pending byte to remain, got %q", c)
+	} else if len(b.Buffer) != 100 || cap(b.Buffer) != 100 {
+		t.Errorf("expecting length and capacity 100, got %d and %d", len(b.Buffer), cap(b.Buffer))
+	}
+
+	b = NewBoundedBuffer(16, 100)
+
+	if n, err := b.ReadFrom(io.MultiReader(strings.NewReader(strings.Repeat("a", 101)))); n != 100 || err != io.ErrShortWrite {
+		t.Errorf("expecting to read 100 bytes with io.ErrShortWrite, read %d with %v", n, err)
+	}
+}
+
+func TestBoundedBufferGrow(t *testing.T) {
+	b := NewBoundedBuffer(0, 5)
+
+	if b.Grow(100); cap(b.Buffer) != 5 {
+		t.Errorf("expecting capacity 5, got %d", cap(b.Buffer))
+	} else if n, err := b.WriteRune('世'); n != 3 || err != nil {
+		t.Errorf("expecting to write 3 bytes with nil error, wrote %d with %v", n, err)
+	} else if n, err = b.WriteRune('界'); n != 0 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 0 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if string(b.Buffer) != "世" {
+		t.Errorf("expecting %q, got %q", "世", b.Buffer)
+	}
+}
@@ -1,11 +1,16 @@
 package memio
 
 import (
+	"bytes"
 	"io"
 	"unicode/utf8"
 )
 
 // Buffer grants a byte slice very straightforward IO methods.
+//
+// Buffer has the methods of bytes.Buffer, other than UnreadByte and
+// UnreadRune, as read bytes are sliced off of the buffer; Mark and ResetTo
+// can be used to restore read bytes instead.
 type Buffer []byte
 
 // Read satisfies the io.Reader interface.
@@ -29,6 +34,12 @@ func (s *Buffer) Read(p []byte) (int, error) {
 // Care should be taken when used in conjunction with any other Read* calls as
 // they will alter the start point of the buffer.
 func (s *Buffer) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= int64(len(*s)) {
+		return 0, io.EOF
+	}
+
 	n := copy(p, (*s)[off:])
 	if n < len(p) {
 		return n, io.EOF
@@ -40,7 +51,7 @@ func (s *Buffer) ReadAt(p []byte, off int64) (int, error) {
 // WriteTo satisfies the io.WriterTo interface.
 func (s *Buffer) WriteTo(w io.Writer) (int64, error) {
 	if len(*s) == 0 {
-		return 0, io.EOF
+		return 0, nil
 	}
 
 	n, err := w.Write(*s)
@@ -57,16 +68,26 @@ func (s *Buffer) Write(p []byte) (int, error) {
 
 // WriteAt satisfies the io.WriteAt interface.
 func (s *Buffer) WriteAt(p []byte, off int64) (int, error) {
+	if !validRange(off, int64(len(p))) {
+		return 0, opError("writeat", off, ErrInvalidOffset)
+	} else if len(p) == 0 {
+		return 0, nil
+	}
+
 	l := int64(len(p)) + off
-	if int64(cap(*s)) < l {
+	if l > maxAlloc {
+		return 0, opError("writeat", off, ErrTooLarge)
+	} else if int64(cap(*s)) < l {
 		t := make([]byte, len(*s), l)
 
-		copy(t, (*s)[:cap(*s)])
+		copy(t, *s)
 
 		*s = t
 	}
 
-	return copy((*s)[off:cap(*s)], p), nil
+	s.extend(int(l))
+
+	return copy((*s)[off:], p), nil
 }
 
 // WriteString writes a string to the buffer without casting to a byte slice.
@@ -77,12 +98,19 @@ func (s *Buffer) WriteString(str string) (int, error) {
 }
 
 // ReadFrom satisfies the io.ReaderFrom interface.
+//
+// When the size of the remaining data in the reader can be determined, the
+// buffer is grown once, in advance, to accommodate it.
 func (s *Buffer) ReadFrom(r io.Reader) (int64, error) {
 	var n int64
 
+	if m := readSize(r, len(*s)); m > 0 {
+		s.reserve(m)
+	}
+
 	for {
 		if len(*s) == cap(*s) {
-			*s = append(*s, 0)[:len(*s)]
+			s.reserve(len(*s) + minRead)
 		}
 
 		m, err := r.Read((*s)[len(*s):cap(*s)])
@@ -99,6 +127,32 @@ func (s *Buffer) ReadFrom(r io.Reader) (int64, error) {
 	}
 }
 
+// extend grows the length of the buffer, within its capacity, to at least l,
+// zeroing any newly exposed bytes.
+func (s *Buffer) extend(l int) {
+	if n := len(*s); l > n {
+		*s = (*s)[:l]
+
+		clear((*s)[n:])
+	}
+}
+
+// reserve ensures that there is space for at least n more bytes, growing the
+// buffer by at least double when reallocation is required.
+func (s *Buffer) reserve(n int) {
+	if cap(*s)-len(*s) >= n {
+		return
+	}
+
+	c := max(int(min(int64(cap(*s))<<1, maxAlloc)), len(*s)+n)
+
+	buf := make([]byte, len(*s), c)
+
+	copy(buf, *s)
+
+	*s = buf
+}
+
 // ReadByte satisfies the io.ByteReader interface.
 func (s *Buffer) ReadByte() (byte, error) {
 	if len(*s) == 0 {
@@ -130,10 +184,124 @@ func (s *Buffer) WriteByte(b byte) error {
 	return nil
 }
 
+// WriteRune satisfies the io.RuneWriter interface.
+func (s *Buffer) WriteRune(r rune) (int, error) {
+	l := len(*s)
+	*s = utf8.AppendRune(*s, r)
+
+	return len(*s) - l, nil
+}
+
+// ReadBytes reads until the first occurrence of delim, returning a copy of the
+// bytes up to and including it.
+//
+// If delim is not found, the remaining bytes are returned along with io.EOF.
+func (s *Buffer) ReadBytes(delim byte) ([]byte, error) {
+	line, err := s.readSlice(delim)
+
+	return bytes.Clone(line), err
+}
+
+// ReadString reads until the first occurrence of delim, returning a string of
+// the bytes up to and including it.
+//
+// If delim is not found, the remaining bytes are returned along with io.EOF.
+func (s *Buffer) ReadString(delim byte) (string, error) {
+	line, err := s.readSlice(delim)
+
+	return string(line), err
+}
+
+func (s *Buffer) readSlice(delim byte) ([]byte, error) {
+	n := bytes.IndexByte(*s, delim)
+	if n < 0 {
+		line := *s
+		*s = (*s)[len(*s):]
+
+		return line, io.EOF
+	}
+
+	line := (*s)[:n+1]
+	*s = (*s)[n+1:]
+
+	return line, nil
+}
+
+// Next returns a slice containing the next n bytes, advancing the buffer as if
+// they had been read. If there are fewer than n bytes, all are returned.
+//
+// The slice is only valid until the next write.
+func (s *Buffer) Next(n int) []byte {
+	n = min(max(n, 0), len(*s))
+	p := (*s)[:n:n]
+	*s = (*s)[n:]
+
+	return p
+}
+
+// Len returns the number of unread bytes.
+func (s *Buffer) Len() int {
+	return len(*s)
+}
+
+// Cap returns the capacity of the buffer.
+func (s *Buffer) Cap() int {
+	return cap(*s)
+}
+
+// Bytes returns the unread bytes, which are only valid until the next
+// modification of the buffer.
+func (s *Buffer) Bytes() []byte {
+	return *s
+}
+
+// String returns the unread bytes as a string.
+//
+// If the Buffer is a nil pointer, it returns "<nil>".
+func (s *Buffer) String() string {
+	if s == nil {
+		return "<nil>"
+	}
+
+	return string(*s)
+}
+
+// AvailableBuffer returns an empty slice with the unused capacity of the
+// buffer, to be appended to and passed to an immediately following Write.
+func (s *Buffer) AvailableBuffer() []byte {
+	return (*s)[len(*s):]
+}
+
+// Grow grows the capacity of the buffer, if necessary, to guarantee space for
+// another n bytes.
+//
+// A negative n, or one that would take the buffer beyond the largest size that
+// can be allocated, is ignored, where bytes.Buffer would panic.
+func (s *Buffer) Grow(n int) {
+	if n > 0 && fits(len(*s), n) {
+		s.reserve(n)
+	}
+}
+
+// Reset empties the buffer, retaining its capacity for future writes.
+func (s *Buffer) Reset() {
+	*s = (*s)[:0]
+}
+
+// Truncate discards all but the first n unread bytes.
+//
+// Unlike bytes.Buffer, which panics, n is clamped to the length of the buffer,
+// so a negative n empties it and one beyond the length leaves it unchanged.
+func (s *Buffer) Truncate(n int) {
+	*s = (*s)[:min(max(n, 0), len(*s))]
+}
+
 // Peek reads the next n bytes without advancing the position.
 func (s *Buffer) Peek(n int) ([]byte, error) {
 	if *s == nil {
-		return nil, ErrClosed
+		return nil, opError("peek", 0, ErrClosed)
+	} else if n < 0 {
+		return nil, opError("peek", 0, ErrInvalidCount)
 	} else if n > len(*s) {
 		return *s, io.EOF
 	}
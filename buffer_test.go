@@ -1,7 +1,10 @@
 package memio
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"math"
 	"testing"
 )
 
@@ -11,6 +14,10 @@ var (
 	_ io.WriterTo = &Buffer{}
 	_ io.ReaderAt = &Buffer{}
 	_ io.WriterAt = &Buffer{}
+	_ interface {
+		WriteRune(rune) (int, error)
+	} = &Buffer{}
+	_ fmt.Stringer = &Buffer{}
 )
 
 func TestBufferRead(t *testing.T) {
@@ -66,3 +73,61 @@ func TestBufferWrite(t *testing.T) {
 		t.Errorf("expecting %q, got %q", "Johnny", string(data))
 	}
 }
+
+func TestBufferBytesBuffer(t *testing.T) {
+	var b Buffer
+
+	if n, err := b.WriteRune('世'); n != 3 || err != nil {
+		t.Errorf("expecting to write 3 bytes with nil error, wrote %d with %v", n, err)
+	} else if n, err = b.WriteRune(-1); n != 3 || err != nil {
+		t.Errorf("expecting to write 3 bytes with nil error, wrote %d with %v", n, err)
+	} else if b.String() != "世\uFFFD" {
+		t.Errorf("expecting %q, got %q", "世\uFFFD", b.String())
+	} else if b.Reset(); b.Len() != 0 || b.Cap() < 6 {
+		t.Errorf("expecting empty buffer with retained capacity, got length %d, capacity %d", b.Len(), b.Cap())
+	} else if b.WriteString("a,bc,d"); string(b.Next(1)) != "a" {
+		t.Errorf("expecting to get %q", "a")
+	} else if line, err := b.ReadBytes(','); string(line) != "," || err != nil {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", ",", line, err)
+	} else if str, err := b.ReadString(','); str != "bc," || err != nil {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", "bc,", str, err)
+	} else if str, err = b.ReadString(','); str != "d" || err != io.EOF {
+		t.Errorf("expecting to read %q with io.EOF, read %q with %v", "d", str, err)
+	} else if b.Len() != 0 {
+		t.Errorf("expecting empty buffer, got %q", b.Bytes())
+	} else if b = Buffer("abcdef"); string(b.Next(10)) != "abcdef" {
+		t.Errorf("expecting to get %q", "abcdef")
+	} else if b = Buffer("abcdef"); len(b.Next(-1)) != 0 {
+		t.Errorf("expecting no bytes")
+	} else if b.Truncate(3); string(b.Bytes()) != "abc" {
+		t.Errorf("expecting %q, got %q", "abc", b.Bytes())
+	} else if b.Truncate(4); string(b) != "abc" {
+		t.Errorf("expecting %q, got %q", "abc", b)
+	} else if b.Grow(100); b.Cap()-b.Len() < 100 {
+		t.Errorf("expecting at least 100 bytes of space, got %d", b.Cap()-b.Len())
+	} else if string(b) != "abc" {
+		t.Errorf("expecting %q, got %q", "abc", b)
+	} else if p := b.AvailableBuffer(); len(p) != 0 || cap(p) != b.Cap()-b.Len() {
+		t.Errorf("expecting empty slice with capacity %d, got length %d, capacity %d", b.Cap()-b.Len(), len(p), cap(p))
+	} else if b.Write(append(b.AvailableBuffer(), "def"...)); string(b) != "abcdef" {
+		t.Errorf("expecting %q, got %q", "abcdef", b)
+	} else if b.Truncate(-1); b.Len() != 0 {
+		t.Errorf("expecting empty buffer, got %q", b)
+	} else if str = (*Buffer)(nil).String(); str != "<nil>" {
+		t.Errorf("expecting %q, got %q", "<nil>", str)
+	}
+}
+
+func TestBufferTooLarge(t *testing.T) {
+	var s Buffer
+
+	if _, err := s.WriteAt([]byte("a"), 1<<62); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	} else if s.Grow(math.MaxInt); cap(s) != 0 {
+		t.Errorf("expecting Grow to be ignored, got capacity %d", cap(s))
+	} else if s.WriteString("abc"); cap(s) > 8 {
+		t.Errorf("expecting capacity of at most 8, got %d", cap(s))
+	} else if s.Grow(math.MaxInt - 1); cap(s) > 8 {
+		t.Errorf("expecting Grow to be ignored, got capacity %d", cap(s))
+	}
+}
@@ -0,0 +1,271 @@
+package memio
+
+import (
+	"container/list"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// CacheStats contains the hit and miss counts of a CachedReaderAt.
+type CacheStats struct {
+	Hits, Misses, Prefetches uint64
+}
+
+type cachePage struct {
+	index int64
+	data  []byte
+	err   error
+	ready chan struct{}
+	elem  *list.Element
+}
+
+// CachedReaderAt wraps an io.ReaderAt that is expensive to call, fetching
+// fixed-size pages into memory on demand.
+//
+// Pages are kept in a least-recently-used cache that is bounded by the total
+// size of the pages. Concurrent reads of the same page share a single fetch.
+//
+// ReadAt may be called concurrently; the Read, ReadByte, Seek and Peek methods,
+// which use a shared position, may not.
+type CachedReaderAt struct {
+	r        io.ReaderAt
+	size     int64
+	pageSize int
+	maxBytes int
+	prefetch atomic.Int32
+
+	mu    sync.Mutex
+	pages map[int64]*cachePage
+	lru   list.List
+	bytes int
+
+	hits, misses, prefetches atomic.Uint64
+
+	pos int64
+}
+
+// NewCachedReaderAt creates a CachedReaderAt over the first size bytes of the
+// given io.ReaderAt, using pages of pageSize bytes and caching no more than
+// maxBytes bytes of pages.
+func NewCachedReaderAt(r io.ReaderAt, size int64, pageSize, maxBytes int) *CachedReaderAt {
+	if pageSize <= 0 {
+		pageSize = 4096
+	}
+
+	return &CachedReaderAt{
+		r:        r,
+		size:     size,
+		pageSize: pageSize,
+		maxBytes: maxBytes,
+		pages:    make(map[int64]*cachePage),
+	}
+}
+
+// SetPrefetch sets the number of pages to fetch ahead, in the background, when
+// reading sequentially with Read.
+func (c *CachedReaderAt) SetPrefetch(pages int) {
+	c.prefetch.Store(int32(pages))
+}
+
+// Stats returns the hit and miss counts of the cache.
+func (c *CachedReaderAt) Stats() CacheStats {
+	return CacheStats{
+		Hits:       c.hits.Load(),
+		Misses:     c.misses.Load(),
+		Prefetches: c.prefetches.Load(),
+	}
+}
+
+// Size returns the size of the underlying data.
+func (c *CachedReaderAt) Size() int64 {
+	return c.size
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (c *CachedReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= c.size {
+		return 0, io.EOF
+	}
+
+	var n int
+
+	for n < len(p) && off < c.size {
+		page, err := c.page(off / int64(c.pageSize))
+		if err != nil {
+			return n, err
+		}
+
+		m := copy(p[n:], page[off%int64(c.pageSize):])
+		n += m
+		off += int64(m)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// Read is an implementation of the io.Reader interface.
+func (c *CachedReaderAt) Read(p []byte) (int, error) {
+	if c.pos >= c.size {
+		return 0, io.EOF
+	}
+
+	n, err := c.ReadAt(p, c.pos)
+	c.pos += int64(n)
+
+	if prefetch := int64(c.prefetch.Load()); prefetch > 0 {
+		next := (c.pos + int64(c.pageSize) - 1) / int64(c.pageSize)
+
+		for i := next; i < next+prefetch && i*int64(c.pageSize) < c.size; i++ {
+			c.prefetchPage(i)
+		}
+	}
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+// ReadByte is an implementation of the io.ByteReader interface.
+func (c *CachedReaderAt) ReadByte() (byte, error) {
+	var b [1]byte
+
+	if _, err := c.Read(b[:]); err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
+
+// Seek is an implementation of the io.Seeker interface.
+func (c *CachedReaderAt) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += c.pos
+	case seekEnd:
+		offset += c.size
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	c.pos = offset
+
+	return offset, nil
+}
+
+// Peek reads the next n bytes without advancing the position.
+func (c *CachedReaderAt) Peek(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, opError("peek", c.pos, ErrInvalidCount)
+	}
+
+	buf := make([]byte, n)
+
+	m, err := c.ReadAt(buf, c.pos)
+
+	return buf[:m], err
+}
+
+func (c *CachedReaderAt) page(index int64) ([]byte, error) {
+	c.mu.Lock()
+
+	if p, ok := c.pages[index]; ok {
+		if p.elem != nil {
+			c.lru.MoveToFront(p.elem)
+		}
+
+		c.mu.Unlock()
+		c.hits.Add(1)
+
+		<-p.ready
+
+		return p.data, p.err
+	}
+
+	p := c.startFetch(index)
+
+	c.mu.Unlock()
+	c.misses.Add(1)
+	c.fetch(p)
+
+	return p.data, p.err
+}
+
+func (c *CachedReaderAt) prefetchPage(index int64) {
+	c.mu.Lock()
+
+	if _, ok := c.pages[index]; ok {
+		c.mu.Unlock()
+
+		return
+	}
+
+	p := c.startFetch(index)
+
+	c.mu.Unlock()
+	c.prefetches.Add(1)
+
+	go c.fetch(p)
+}
+
+func (c *CachedReaderAt) startFetch(index int64) *cachePage {
+	p := &cachePage{index: index, ready: make(chan struct{})}
+	c.pages[index] = p
+
+	return p
+}
+
+func (c *CachedReaderAt) fetch(p *cachePage) {
+	off := p.index * int64(c.pageSize)
+	size := int64(c.pageSize)
+
+	if left := c.size - off; left < size {
+		size = left
+	}
+
+	buf := make([]byte, size)
+
+	n, err := c.r.ReadAt(buf, off)
+	if n == len(buf) {
+		err = nil
+	} else if err == nil || err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+
+	p.data = buf[:n]
+	p.err = err
+
+	close(p.ready)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		delete(c.pages, p.index)
+
+		return
+	}
+
+	p.elem = c.lru.PushFront(p)
+	c.bytes += len(buf)
+
+	for c.bytes > c.maxBytes && c.lru.Len() > 1 {
+		old := c.lru.Remove(c.lru.Back()).(*cachePage)
+		c.bytes -= len(old.data)
+
+		delete(c.pages, old.index)
+	}
+}
@@ -0,0 +1,117 @@
+package memio
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+var (
+	_ io.ReadSeeker = new(CachedReaderAt)
+	_ io.ReaderAt   = new(CachedReaderAt)
+	_ io.ByteReader = new(CachedReaderAt)
+)
+
+type countingReaderAt struct {
+	io.ReaderAt
+	calls atomic.Int64
+}
+
+func (c *countingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	c.calls.Add(1)
+
+	return c.ReaderAt.ReadAt(p, off)
+}
+
+func TestCachedReaderAt(t *testing.T) {
+	data := make([]byte, 1000)
+
+	for n := range data {
+		data[n] = byte(n)
+	}
+
+	r := &countingReaderAt{ReaderAt: bytes.NewReader(data)}
+	c := NewCachedReaderAt(r, int64(len(data)), 100, 300)
+	buf := make([]byte, 150)
+
+	if n, err := c.ReadAt(buf, 50); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !bytes.Equal(buf[:n], data[50:200]) {
+		t.Fatalf("read data does not match")
+	} else if calls := r.calls.Load(); calls != 2 {
+		t.Fatalf("expecting 2 calls, got %d", calls)
+	} else if n, err = c.ReadAt(buf[:50], 120); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if calls = r.calls.Load(); calls != 2 {
+		t.Fatalf("expecting 2 calls, got %d", calls)
+	} else if stats := c.Stats(); stats.Hits != 1 || stats.Misses != 2 {
+		t.Fatalf("expecting 1 hit and 2 misses, got %+v", stats)
+	} else if n, err = c.ReadAt(buf, 900); err != io.EOF {
+		t.Fatalf("expecting io.EOF, got %v", err)
+	} else if !bytes.Equal(buf[:n], data[900:]) {
+		t.Fatalf("read data does not match")
+	}
+
+	c.ReadAt(buf[:1], 300)
+	c.ReadAt(buf[:1], 500)
+
+	if c.bytes > 300 {
+		t.Errorf("expecting cache to hold no more than 300 bytes, holds %d", c.bytes)
+	} else if _, ok := c.pages[0]; ok {
+		t.Errorf("expecting page 0 to have been evicted")
+	}
+
+	c.Seek(995, seekSet)
+
+	if p, err := c.Peek(10); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if !bytes.Equal(p, data[995:]) {
+		t.Errorf("peeked data does not match")
+	}
+}
+
+func TestCachedReaderAtConcurrent(t *testing.T) {
+	data := make([]byte, 1000)
+	r := &countingReaderAt{ReaderAt: bytes.NewReader(data)}
+	c := NewCachedReaderAt(r, int64(len(data)), 1000, 1000)
+
+	var wg sync.WaitGroup
+
+	for n := 0; n < 10; n++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			c.ReadAt(make([]byte, 10), 0)
+		}()
+	}
+
+	wg.Wait()
+
+	if calls := r.calls.Load(); calls != 1 {
+		t.Errorf("expecting 1 call, got %d", calls)
+	}
+}
+
+func TestCachedReaderAtPrefetch(t *testing.T) {
+	data := make([]byte, 1000)
+	r := &countingReaderAt{ReaderAt: bytes.NewReader(data)}
+	c := NewCachedReaderAt(r, int64(len(data)), 100, 1000)
+
+	c.SetPrefetch(2)
+
+	var out bytes.Buffer
+
+	if _, err := io.Copy(&out, io.LimitReader(c, 1000)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if out.Len() != 1000 {
+		t.Fatalf("expecting to read 1000 bytes, read %d", out.Len())
+	} else if calls := r.calls.Load(); calls != 10 {
+		t.Errorf("expecting 10 calls, got %d", calls)
+	} else if stats := c.Stats(); stats.Prefetches == 0 {
+		t.Errorf("expecting prefetches, got %+v", stats)
+	}
+}
@@ -33,6 +33,6 @@ func ExampleLimitedBuffer() {
 
 	// Attempting to write more will not extend capacity
 	n, err := lb.WriteString("678")
-	fmt.Println(n, errors.Is(err, io.ErrShortBuffer))
+	fmt.Println(n, errors.Is(err, io.ErrShortWrite))
 	// Output: 0 true
 }
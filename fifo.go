@@ -0,0 +1,233 @@
+package memio
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Cursor flags, to be combined with the whence argument to FIFOMem.Seek.
+const (
+	SeekRead  = 0
+	SeekWrite = 1 << 4
+)
+
+// FIFOMem uses a byte slice for reading and writing, with independent read
+// and write positions, much like a file opened twice.
+//
+// Writes, including those of the embedded WriteMem, happen at the write
+// position, while reads consume from the read position. Unlike with Buffer,
+// read data is kept, so it can be read again by seeking back.
+//
+// InsertAt and DeleteRange adjust the read position, as they do the write
+// position, and Truncate moves the read position back to the new end, leaving
+// the write position, as with WriteMem, unchanged. Move, Fill, WriteAt and
+// Sections overwrite data in place, leaving both positions unchanged.
+type FIFOMem struct {
+	WriteMem
+	read int
+}
+
+// OpenFIFO uses a byte slice for reading and writing, with the read position
+// at the start of the data and the write position at its end. Implements
+// io.Reader, io.Writer, io.Seeker, io.ReaderAt, io.ByteScanner,
+// io.RuneReader, io.WriterTo, io.WriterAt, io.ByteWriter and io.ReaderFrom.
+func OpenFIFO(data *[]byte) *FIFOMem {
+	return &FIFOMem{WriteMem: WriteMem{data: data, pos: len(*data)}}
+}
+
+// Len returns the number of unread bytes.
+func (f *FIFOMem) Len() int {
+	if f.data == nil {
+		return 0
+	}
+
+	return max(len(*f.data)-f.read, 0)
+}
+
+// Peek reads the next n bytes without advancing the read position.
+func (f *FIFOMem) Peek(n int) ([]byte, error) {
+	if f.data == nil {
+		return nil, opError("peek", int64(f.read), ErrClosed)
+	} else if n < 0 {
+		return nil, opError("peek", int64(f.read), ErrInvalidCount)
+	} else if f.read >= len(*f.data) {
+		return nil, io.EOF
+	} else if n > len(*f.data)-f.read {
+		return (*f.data)[f.read:], io.EOF
+	}
+
+	return (*f.data)[f.read : f.read+n], nil
+}
+
+// Read is an implementation of the io.Reader interface.
+func (f *FIFOMem) Read(p []byte) (int, error) {
+	if f.data == nil {
+		return 0, opError("read", int64(f.read), ErrClosed)
+	} else if f.read >= len(*f.data) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, (*f.data)[f.read:])
+	f.read += n
+
+	return n, nil
+}
+
+// ReadByte is an implementation of the io.ByteReader interface.
+func (f *FIFOMem) ReadByte() (byte, error) {
+	if f.data == nil {
+		return 0, opError("readbyte", int64(f.read), ErrClosed)
+	} else if f.read >= len(*f.data) {
+		return 0, io.EOF
+	}
+
+	c := (*f.data)[f.read]
+	f.read++
+
+	return c, nil
+}
+
+// ReadRune is an implementation of the io.RuneReader interface.
+func (f *FIFOMem) ReadRune() (rune, int, error) {
+	if f.data == nil {
+		return 0, 0, opError("readrune", int64(f.read), ErrClosed)
+	} else if f.read >= len(*f.data) {
+		return 0, 0, io.EOF
+	}
+
+	r, n := utf8.DecodeRune((*f.data)[f.read:])
+	f.read += n
+
+	return r, n, nil
+}
+
+// UnreadByte implements the io.ByteScanner interface.
+func (f *FIFOMem) UnreadByte() error {
+	if f.data == nil {
+		return opError("unreadbyte", int64(f.read), ErrClosed)
+	}
+
+	if f.read > 0 {
+		f.read--
+
+		return nil
+	}
+
+	return ErrInvalidUnreadByte
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (f *FIFOMem) ReadAt(p []byte, off int64) (int, error) {
+	if f.data == nil {
+		return 0, opError("readat", off, ErrClosed)
+	} else if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= int64(len(*f.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, (*f.data)[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// WriteTo is an implementation of the io.WriterTo interface, writing the
+// unread data.
+func (f *FIFOMem) WriteTo(w io.Writer) (int64, error) {
+	if f.data == nil {
+		return 0, opError("writeto", int64(f.read), ErrClosed)
+	} else if f.read >= len(*f.data) {
+		return 0, nil
+	}
+
+	n, err := writeAligned(w, (*f.data)[f.read:], f.align)
+	f.read += int(n)
+
+	return n, err
+}
+
+// Seek is an implementation of the io.Seeker interface.
+//
+// By default, or when combined with SeekRead, the whence argument moves the
+// read position; when combined with SeekWrite, it moves the write position.
+// The other position is left unchanged.
+func (f *FIFOMem) Seek(offset int64, whence int) (int64, error) {
+	if whence&SeekWrite != 0 {
+		return f.WriteMem.Seek(offset, whence&^SeekWrite)
+	} else if f.data == nil {
+		return 0, opError("seek", offset, ErrClosed)
+	}
+
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += int64(f.read)
+	case seekEnd:
+		offset += int64(len(*f.data))
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 || offset > maxInt {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	f.read = int(offset)
+
+	return offset, nil
+}
+
+// InsertAt inserts the given bytes at the given offset, moving any following
+// bytes forward. A read or write position after the offset is moved forward by
+// the number of bytes inserted.
+func (f *FIFOMem) InsertAt(off int64, p []byte) (int, error) {
+	n, err := f.WriteMem.InsertAt(off, p)
+	if err == nil && int64(f.read) > off {
+		f.read += n
+	}
+
+	return n, err
+}
+
+// DeleteRange removes n bytes starting at the given offset, moving any
+// following bytes back. A read or write position after the removed bytes is
+// moved back by the number of bytes removed, and a position within them is
+// moved to the offset.
+func (f *FIFOMem) DeleteRange(off, n int64) error {
+	var l int
+
+	if f.data != nil {
+		l = len(*f.data)
+	}
+
+	if err := f.WriteMem.DeleteRange(off, n); err != nil {
+		return err
+	}
+
+	o, m := int(off), l-len(*f.data)
+
+	if f.read > o+m {
+		f.read -= m
+	} else if f.read > o {
+		f.read = o
+	}
+
+	return nil
+}
+
+// Truncate changes the length of the byte slice to the given amount, moving
+// the read position back to the new end if it was beyond it.
+func (f *FIFOMem) Truncate(s int64) error {
+	if err := f.WriteMem.Truncate(s); err != nil {
+		return err
+	}
+
+	if int64(f.read) > s {
+		f.read = int(s)
+	}
+
+	return nil
+}
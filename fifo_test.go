@@ -0,0 +1,133 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ io.Reader      = new(FIFOMem)
+	_ io.Writer      = new(FIFOMem)
+	_ io.Seeker      = new(FIFOMem)
+	_ io.ReaderAt    = new(FIFOMem)
+	_ io.WriterAt    = new(FIFOMem)
+	_ io.ByteScanner = new(FIFOMem)
+	_ io.RuneReader  = new(FIFOMem)
+	_ io.ByteWriter  = new(FIFOMem)
+	_ io.WriterTo    = new(FIFOMem)
+	_ io.ReaderFrom  = new(FIFOMem)
+)
+
+func TestFIFOMem(t *testing.T) {
+	data := []byte("Hello")
+	f := OpenFIFO(&data)
+	buf := make([]byte, 3)
+
+	var sb strings.Builder
+
+	if n, err := f.Read(buf); n != 3 || err != nil {
+		t.Errorf("expecting to read 3 bytes with nil error, read %d with %v", n, err)
+	} else if string(buf) != "Hel" {
+		t.Errorf("expecting %q, got %q", "Hel", buf)
+	} else if n, err = f.WriteString(", World"); n != 7 || err != nil {
+		t.Errorf("expecting to write 7 bytes with nil error, wrote %d with %v", n, err)
+	} else if f.Len() != 9 {
+		t.Errorf("expecting 9 unread bytes, got %d", f.Len())
+	} else if n, err = f.Read(buf); n != 3 || err != nil {
+		t.Errorf("expecting to read 3 bytes with nil error, read %d with %v", n, err)
+	} else if string(buf) != "lo," {
+		t.Errorf("expecting %q, got %q", "lo,", buf)
+	} else if err = f.WriteByte('!'); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if c, err := f.ReadByte(); c != ' ' || err != nil {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", ' ', c, err)
+	} else if err = f.UnreadByte(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if p, err := f.Peek(3); string(p) != " Wo" || err != nil {
+		t.Errorf("expecting to peek %q with nil error, peeked %q with %v", " Wo", p, err)
+	} else if pos, err := f.Seek(0, io.SeekCurrent|SeekWrite); pos != 13 || err != nil {
+		t.Errorf("expecting write position 13 with nil error, got %d with %v", pos, err)
+	} else if pos, err = f.Seek(0, io.SeekCurrent); pos != 6 || err != nil {
+		t.Errorf("expecting read position 6 with nil error, got %d with %v", pos, err)
+	} else if pos, err = f.Seek(0, io.SeekStart|SeekRead); pos != 0 || err != nil {
+		t.Errorf("expecting read position 0 with nil error, got %d with %v", pos, err)
+	} else if pos, err = f.Seek(-1, io.SeekEnd|SeekWrite); pos != 12 || err != nil {
+		t.Errorf("expecting write position 12 with nil error, got %d with %v", pos, err)
+	} else if _, err = f.WriteString("?"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if r, _, err := f.ReadRune(); r != 'H' || err != nil {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", 'H', r, err)
+	} else if n, err := f.WriteTo(&sb); n != 12 || err != nil {
+		t.Errorf("expecting to write 12 bytes with nil error, wrote %d with %v", n, err)
+	} else if sb.String() != "ello, World?" {
+		t.Errorf("expecting %q, got %q", "ello, World?", sb.String())
+	} else if _, err = f.Read(buf); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if n, err := f.ReadFrom(strings.NewReader("abc")); n != 3 || err != nil {
+		t.Errorf("expecting to read 3 bytes with nil error, read %d with %v", n, err)
+	} else if n, err := f.Read(buf); n != 3 || err != nil || string(buf) != "abc" {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", "abc", buf[:n], err)
+	} else if n, err = f.ReadAt(buf, 7); n != 3 || err != nil || string(buf) != "Wor" {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", "Wor", buf[:n], err)
+	} else if string(data) != "Hello, World?abc" {
+		t.Errorf("expecting %q, got %q", "Hello, World?abc", data)
+	} else if _, err = f.Seek(-1, io.SeekStart); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if _, err = f.Seek(0, 3); !errors.Is(err, ErrInvalidWhence) {
+		t.Errorf("expecting ErrInvalidWhence, got %v", err)
+	} else if f.Close(); !errors.Is(f.UnreadByte(), ErrClosed) {
+		t.Errorf("expecting ErrClosed")
+	}
+}
+
+func TestFIFOMemEdit(t *testing.T) {
+	data := []byte("Hello, World")
+	f := OpenFIFO(&data)
+	buf := make([]byte, 5)
+
+	var sb strings.Builder
+
+	if _, err := f.Seek(7, io.SeekStart); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = f.InsertAt(5, []byte(" there")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err := f.Read(buf); n != 5 || err != nil || string(buf) != "World" {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", "World", buf[:n], err)
+	} else if _, err = f.Seek(13, io.SeekStart); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if err = f.DeleteRange(0, 7); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err = f.Read(buf); n != 5 || err != nil || string(buf) != "World" {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", "World", buf[:n], err)
+	} else if _, err = f.Seek(3, io.SeekStart); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if err = f.DeleteRange(1, 5); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err = f.Read(buf); n != 5 || err != nil || string(buf) != "World" {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", "World", buf[:n], err)
+	} else if err = f.Move(0, 1, 5); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = f.Section(5, 1).Write([]byte("!")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if pos, err := f.Seek(0, io.SeekCurrent); pos != 6 || err != nil {
+		t.Errorf("expecting read position 6 with nil error, got %d with %v", pos, err)
+	} else if string(data) != "World!" {
+		t.Errorf("expecting %q, got %q", "World!", data)
+	} else if err = f.Truncate(2); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if pos, err = f.Seek(0, io.SeekCurrent); pos != 2 || err != nil {
+		t.Errorf("expecting read position 2 with nil error, got %d with %v", pos, err)
+	} else if _, err = f.Seek(0, io.SeekEnd|SeekWrite); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = f.WriteString("rld"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = f.WriteTo(&sb); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if sb.String() != "rld" {
+		t.Errorf("expecting %q, got %q", "rld", sb.String())
+	} else if f.Close(); !errors.Is(f.DeleteRange(0, 1), ErrClosed) {
+		t.Errorf("expecting ErrClosed")
+	}
+}
@@ -0,0 +1,240 @@
+package memio
+
+import (
+	"io"
+)
+
+// GapBuffer is a byte buffer optimised for insertions and deletions around a
+// cursor, as is common when editing text.
+//
+// The unused capacity of the buffer is kept as a gap at the cursor position,
+// making insertions at the cursor amortised O(1), while moving the cursor
+// costs time proportional to the distance moved.
+//
+// The Read, Seek, ReadAt and WriteTo methods operate on the logical contents
+// of the buffer, with the gap hidden, and use a read position that is
+// independent of the cursor.
+type GapBuffer struct {
+	data             []byte
+	gapStart, gapEnd int
+	pos              int
+}
+
+// NewGapBuffer creates a GapBuffer with the given initial contents, and with
+// the cursor at the end of the data. The spare capacity of the slice is used
+// as the initial gap.
+func NewGapBuffer(data []byte) *GapBuffer {
+	return &GapBuffer{
+		data:     data[:cap(data)],
+		gapStart: len(data),
+		gapEnd:   cap(data),
+	}
+}
+
+// Len returns the length of the logical contents of the buffer.
+func (g *GapBuffer) Len() int {
+	return len(g.data) - g.gapEnd + g.gapStart
+}
+
+// Cursor returns the current cursor position.
+func (g *GapBuffer) Cursor() int {
+	return g.gapStart
+}
+
+// MoveCursor moves the cursor to the given position.
+func (g *GapBuffer) MoveCursor(pos int) error {
+	if pos < 0 || pos > g.Len() {
+		return opError("movecursor", int64(pos), ErrInvalidOffset)
+	}
+
+	if pos < g.gapStart {
+		n := g.gapStart - pos
+
+		copy(g.data[g.gapEnd-n:g.gapEnd], g.data[pos:g.gapStart])
+
+		g.gapStart = pos
+		g.gapEnd -= n
+	} else if pos > g.gapStart {
+		n := pos - g.gapStart
+
+		copy(g.data[g.gapStart:], g.data[g.gapEnd:g.gapEnd+n])
+
+		g.gapStart = pos
+		g.gapEnd += n
+	}
+
+	return nil
+}
+
+// Insert inserts the given bytes at the cursor, leaving the cursor after the
+// inserted bytes.
+func (g *GapBuffer) Insert(p []byte) {
+	g.grow(len(p))
+
+	if g.pos > g.gapStart {
+		g.pos += len(p)
+	}
+
+	g.gapStart += copy(g.data[g.gapStart:], p)
+}
+
+// InsertString inserts the given string at the cursor, leaving the cursor
+// after the inserted bytes.
+func (g *GapBuffer) InsertString(s string) {
+	g.grow(len(s))
+
+	if g.pos > g.gapStart {
+		g.pos += len(s)
+	}
+
+	g.gapStart += copy(g.data[g.gapStart:], s)
+}
+
+// Delete removes up to n bytes after the cursor, returning the number of bytes
+// removed.
+func (g *GapBuffer) Delete(n int) int {
+	if left := len(g.data) - g.gapEnd; n > left {
+		n = left
+	} else if n < 0 {
+		n = 0
+	}
+
+	if g.pos > g.gapStart+n {
+		g.pos -= n
+	} else if g.pos > g.gapStart {
+		g.pos = g.gapStart
+	}
+
+	g.gapEnd += n
+
+	return n
+}
+
+// DeleteBackward removes up to n bytes before the cursor, returning the number
+// of bytes removed.
+func (g *GapBuffer) DeleteBackward(n int) int {
+	if n > g.gapStart {
+		n = g.gapStart
+	} else if n < 0 {
+		n = 0
+	}
+
+	g.gapStart -= n
+
+	if g.pos > g.gapStart+n {
+		g.pos -= n
+	} else if g.pos > g.gapStart {
+		g.pos = g.gapStart
+	}
+
+	return n
+}
+
+// Read is an implementation of the io.Reader interface.
+func (g *GapBuffer) Read(p []byte) (int, error) {
+	if g.pos >= g.Len() {
+		return 0, io.EOF
+	}
+
+	n, _ := g.ReadAt(p, int64(g.pos))
+	g.pos += n
+
+	return n, nil
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (g *GapBuffer) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= int64(g.Len()) {
+		return 0, io.EOF
+	}
+
+	var n int
+
+	if o := int(off); o < g.gapStart {
+		n = copy(p, g.data[o:g.gapStart])
+		n += copy(p[n:], g.data[g.gapEnd:])
+	} else {
+		n = copy(p, g.data[g.gapEnd+o-g.gapStart:])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// Seek is an implementation of the io.Seeker interface.
+func (g *GapBuffer) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += int64(g.pos)
+	case seekEnd:
+		offset += int64(g.Len())
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 || offset > maxInt {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	g.pos = int(offset)
+
+	return offset, nil
+}
+
+// WriteTo is an implementation of the io.WriterTo interface.
+func (g *GapBuffer) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+
+	if g.pos < g.gapStart {
+		n, err := w.Write(g.data[g.pos:g.gapStart])
+		total += int64(n)
+		g.pos += n
+
+		if err != nil {
+			return total, err
+		}
+	}
+
+	if l := g.Len(); g.pos < l {
+		n, err := w.Write(g.data[g.gapEnd+g.pos-g.gapStart:])
+		total += int64(n)
+		g.pos += n
+
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func (g *GapBuffer) grow(n int) {
+	if g.gapEnd-g.gapStart >= n {
+		return
+	}
+
+	l := g.Len() + n
+
+	var c int
+
+	if l < 512 {
+		c = l << 1
+	} else {
+		c = l + (l >> 2)
+	}
+
+	data := make([]byte, c)
+	tail := len(g.data) - g.gapEnd
+
+	copy(data, g.data[:g.gapStart])
+	copy(data[c-tail:], g.data[g.gapEnd:])
+
+	g.data = data
+	g.gapEnd = c - tail
+}
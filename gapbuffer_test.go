@@ -0,0 +1,81 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ io.Reader   = new(GapBuffer)
+	_ io.Seeker   = new(GapBuffer)
+	_ io.ReaderAt = new(GapBuffer)
+	_ io.WriterTo = new(GapBuffer)
+)
+
+func TestGapBuffer(t *testing.T) {
+	g := NewGapBuffer(nil)
+
+	for n, test := range [...]struct {
+		Action   func()
+		Expected string
+		Cursor   int
+	}{
+		{func() { g.InsertString("Hello World") }, "Hello World", 11},
+		{func() { g.MoveCursor(5) }, "Hello World", 5},
+		{func() { g.InsertString(",") }, "Hello, World", 6},
+		{func() { g.Delete(1) }, "Hello,World", 6},
+		{func() { g.Insert([]byte(" there ")) }, "Hello, there World", 13},
+		{func() { g.DeleteBackward(7) }, "Hello,World", 6},
+		{func() { g.MoveCursor(11) }, "Hello,World", 11},
+		{func() { g.InsertString("!") }, "Hello,World!", 12},
+		{func() { g.MoveCursor(0) }, "Hello,World!", 0},
+		{func() { g.Delete(100) }, "", 0},
+	} {
+		test.Action()
+
+		var sb strings.Builder
+
+		g.Seek(0, seekSet)
+		g.WriteTo(&sb)
+
+		if s := sb.String(); s != test.Expected {
+			t.Errorf("test %d: expecting contents %q, got %q", n+1, test.Expected, s)
+		} else if g.Len() != len(test.Expected) {
+			t.Errorf("test %d: expecting length %d, got %d", n+1, len(test.Expected), g.Len())
+		} else if g.Cursor() != test.Cursor {
+			t.Errorf("test %d: expecting cursor %d, got %d", n+1, test.Cursor, g.Cursor())
+		}
+	}
+}
+
+func TestGapBufferRead(t *testing.T) {
+	g := NewGapBuffer([]byte("Hello, World!"))
+
+	g.MoveCursor(4)
+
+	buf := make([]byte, 5)
+
+	if n, err := g.ReadAt(buf, 2); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != "llo, " {
+		t.Errorf("expecting %q, got %q", "llo, ", buf[:n])
+	} else if n, err = g.ReadAt(buf, 10); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if string(buf[:n]) != "ld!" {
+		t.Errorf("expecting %q, got %q", "ld!", buf[:n])
+	} else if _, err = g.Seek(7, seekSet); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	g.InsertString("big ")
+
+	if n, err := g.Read(buf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != "World" {
+		t.Errorf("expecting %q, got %q", "World", buf[:n])
+	} else if _, err = g.Seek(-1, seekSet); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	}
+}
@@ -1,3 +1,3 @@
 module vimagination.zapto.org/memio
 
-go 1.16
+go 1.23
@@ -30,9 +30,15 @@ func (s *LimitedBuffer) Read(p []byte) (int, error) {
 // Care should be taken when used in conjunction with any other Read* calls as
 // they will alter the start point of the buffer.
 func (s *LimitedBuffer) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= int64(len(*s)) {
+		return 0, io.EOF
+	}
+
 	n := copy(p, (*s)[off:])
 	if n < len(p) {
-		return n, io.ErrUnexpectedEOF
+		return n, io.EOF
 	}
 
 	return n, nil
@@ -41,7 +47,7 @@ func (s *LimitedBuffer) ReadAt(p []byte, off int64) (int, error) {
 // WriteTo satisfies the io.WriterTo interface.
 func (s *LimitedBuffer) WriteTo(w io.Writer) (int64, error) {
 	if len(*s) == 0 {
-		return 0, io.EOF
+		return 0, nil
 	}
 
 	n, err := w.Write(*s)
@@ -66,11 +72,17 @@ func (s *LimitedBuffer) Write(p []byte) (int, error) {
 
 // WriteAt satisfies the io.WriterAt interface.
 func (s *LimitedBuffer) WriteAt(p []byte, off int64) (int, error) {
-	if off+int64(len(p)) >= int64(cap(p)) {
+	if off < 0 {
+		return 0, opError("writeat", off, ErrInvalidOffset)
+	} else if len(p) == 0 {
+		return 0, nil
+	} else if off >= int64(cap(*s)) {
 		return 0, io.ErrShortWrite
 	}
 
-	n := copy((*s)[off:cap(*s)], p)
+	(*Buffer)(s).extend(int(min(off+int64(len(p)), int64(cap(*s)))))
+
+	n := copy((*s)[off:], p)
 	if n < len(p) {
 		return n, io.ErrShortWrite
 	}
@@ -84,7 +96,7 @@ func (s *LimitedBuffer) WriteString(str string) (int, error) {
 
 	if left := cap(*s) - len(*s); len(str) > left {
 		str = str[:left]
-		err = io.ErrShortBuffer
+		err = io.ErrShortWrite
 	}
 
 	*s = append(*s, str...)
@@ -140,7 +152,7 @@ func (s *LimitedBuffer) ReadRune() (rune, int, error) {
 // WriteByte satisfies the io.ByteWriter interface.
 func (s *LimitedBuffer) WriteByte(b byte) error {
 	if len(*s) == cap(*s) {
-		return io.EOF
+		return io.ErrShortWrite
 	}
 
 	*s = append(*s, b)
@@ -151,7 +163,9 @@ func (s *LimitedBuffer) WriteByte(b byte) error {
 // Peek reads the next n bytes without advancing the position.
 func (s *LimitedBuffer) Peek(n int) ([]byte, error) {
 	if *s == nil {
-		return nil, ErrClosed
+		return nil, opError("peek", 0, ErrClosed)
+	} else if n < 0 {
+		return nil, opError("peek", 0, ErrInvalidCount)
 	} else if n > len(*s) {
 		return *s, io.EOF
 	}
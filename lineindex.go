@@ -0,0 +1,266 @@
+package memio
+
+import (
+	"errors"
+	"sort"
+	"unicode/utf8"
+)
+
+// Unit determines how the columns of a LineIndex are measured.
+type Unit uint8
+
+// Column units.
+const (
+	UnitBytes Unit = iota
+	UnitRunes
+	UnitUTF16
+)
+
+// LineIndex maps byte offsets within a ReadWriteMem to line and column
+// positions, and back.
+//
+// The index is built on first use and is then kept up to date by writes made
+// through the ReadWriteMem. Changes made to the underlying byte slice by other
+// means require a call to Invalidate.
+//
+// Lines and columns are zero-based.
+type LineIndex struct {
+	mem    *WriteMem
+	starts []int
+	built  bool
+}
+
+// LineIndex returns the line index attached to the ReadWriteMem, creating it
+// if necessary.
+func (b *ReadWriteMem) LineIndex() *LineIndex {
+	if b.index == nil {
+		b.index = &LineIndex{mem: &b.WriteMem}
+	}
+
+	return b.index
+}
+
+// SeekLine moves the position to the start of a line, as determined by the
+// line index. The whence values are those used for Seek, with the offset
+// measured in lines, and seekCurr and seekEnd being relative to the current
+// and last lines respectively.
+//
+// Returns the new byte offset.
+func (b *ReadWriteMem) SeekLine(line int64, whence int) (int64, error) {
+	l := b.LineIndex()
+	if err := l.build(); err != nil {
+		return 0, err
+	}
+
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		line += int64(l.line(b.pos))
+	case seekEnd:
+		line += int64(len(l.starts) - 1)
+	default:
+		return 0, opError("seekline", line, ErrInvalidWhence)
+	}
+
+	if line < 0 || line >= int64(len(l.starts)) {
+		return 0, opError("seekline", line, ErrInvalidLine)
+	}
+
+	b.pos = l.starts[line]
+
+	return int64(b.pos), nil
+}
+
+// Invalidate discards the index so that it is rebuilt on next use.
+func (l *LineIndex) Invalidate() {
+	l.starts = l.starts[:0]
+	l.built = false
+}
+
+// Lines returns the number of lines in the buffer. A trailing newline starts
+// a new, empty, line.
+func (l *LineIndex) Lines() (int, error) {
+	if err := l.build(); err != nil {
+		return 0, err
+	}
+
+	return len(l.starts), nil
+}
+
+// LineStart returns the byte offset of the start of the given line.
+func (l *LineIndex) LineStart(line int) (int64, error) {
+	if err := l.build(); err != nil {
+		return 0, err
+	} else if line < 0 || line >= len(l.starts) {
+		return 0, opError("linestart", int64(line), ErrInvalidLine)
+	}
+
+	return int64(l.starts[line]), nil
+}
+
+// Position converts a byte offset into a line and column, with the column
+// measured in the given unit.
+func (l *LineIndex) Position(off int64, unit Unit) (line, col int, err error) {
+	if err := l.build(); err != nil {
+		return 0, 0, err
+	}
+
+	data := *l.mem.data
+
+	if off < 0 || off > int64(len(data)) {
+		return 0, 0, opError("position", off, ErrInvalidOffset)
+	}
+
+	line = l.line(int(off))
+	col = measure(data[l.starts[line]:off], unit)
+
+	return line, col, nil
+}
+
+// Offset converts a line and column, with the column measured in the given
+// unit, into a byte offset.
+func (l *LineIndex) Offset(line, col int, unit Unit) (int64, error) {
+	if err := l.build(); err != nil {
+		return 0, err
+	} else if line < 0 || line >= len(l.starts) {
+		return 0, opError("offset", int64(line), ErrInvalidLine)
+	} else if col < 0 {
+		return 0, opError("offset", int64(l.starts[line]), ErrInvalidColumn)
+	}
+
+	data := *l.mem.data
+	start := l.starts[line]
+	end := len(data)
+
+	if line+1 < len(l.starts) {
+		end = l.starts[line+1] - 1
+	}
+
+	if unit == UnitBytes {
+		if col > end-start {
+			return 0, opError("offset", int64(start), ErrInvalidColumn)
+		}
+
+		return int64(start + col), nil
+	}
+
+	pos := start
+
+	for col > 0 {
+		if pos >= end {
+			return 0, opError("offset", int64(start), ErrInvalidColumn)
+		}
+
+		r, s := utf8.DecodeRune(data[pos:end])
+
+		if unit == UnitUTF16 && r >= 0x10000 {
+			if col == 1 {
+				return 0, opError("offset", int64(start), ErrInvalidColumn)
+			}
+
+			col--
+		}
+
+		col--
+		pos += s
+	}
+
+	return int64(pos), nil
+}
+
+func (l *LineIndex) build() error {
+	if l.mem.data == nil {
+		return opError("lineindex", 0, ErrClosed)
+	} else if l.built {
+		return nil
+	}
+
+	l.starts = append(l.starts[:0], 0)
+
+	for n, c := range *l.mem.data {
+		if c == '\n' {
+			l.starts = append(l.starts, n+1)
+		}
+	}
+
+	l.built = true
+
+	return nil
+}
+
+func (l *LineIndex) line(off int) int {
+	return sort.SearchInts(l.starts, off+1) - 1
+}
+
+// update adjusts the index after removed bytes at off have been replaced by
+// added bytes.
+func (l *LineIndex) update(off, removed, added int) {
+	if l == nil || !l.built {
+		return
+	}
+
+	var ins []int
+
+	for n, c := range (*l.mem.data)[off : off+added] {
+		if c == '\n' {
+			ins = append(ins, off+n+1)
+		}
+	}
+
+	lo := sort.SearchInts(l.starts, off+1)
+	hi := sort.SearchInts(l.starts, off+removed+1)
+	length := len(l.starts) + len(ins) - hi + lo
+
+	if length > cap(l.starts) {
+		starts := make([]int, length, length+length>>2)
+
+		copy(starts, l.starts[:lo])
+		copy(starts[lo+len(ins):], l.starts[hi:])
+
+		l.starts = starts
+	} else {
+		old := l.starts
+		l.starts = l.starts[:length]
+
+		copy(l.starts[lo+len(ins):], old[hi:])
+	}
+
+	copy(l.starts[lo:], ins)
+
+	if delta := added - removed; delta != 0 {
+		for n := lo + len(ins); n < length; n++ {
+			l.starts[n] += delta
+		}
+	}
+}
+
+func measure(p []byte, unit Unit) int {
+	switch unit {
+	case UnitRunes:
+		return utf8.RuneCount(p)
+	case UnitUTF16:
+		var n int
+
+		for len(p) > 0 {
+			r, s := utf8.DecodeRune(p)
+			if r >= 0x10000 {
+				n++
+			}
+
+			n++
+			p = p[s:]
+		}
+
+		return n
+	}
+
+	return len(p)
+}
+
+// Errors.
+var (
+	ErrInvalidLine   = errors.New("invalid line")
+	ErrInvalidColumn = errors.New("invalid column")
+	ErrInvalidOffset = errors.New("invalid offset")
+	ErrInvalidWhence = errors.New("invalid whence")
+)
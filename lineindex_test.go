@@ -0,0 +1,136 @@
+package memio
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLineIndexPosition(t *testing.T) {
+	data := []byte("abc\nd€f\n\U0001F600g\n")
+	l := OpenMem(&data).LineIndex()
+
+	for n, test := range [...]struct {
+		Offset    int64
+		Unit      Unit
+		Line, Col int
+	}{
+		{0, UnitBytes, 0, 0},
+		{3, UnitBytes, 0, 3},
+		{4, UnitBytes, 1, 0},
+		{8, UnitBytes, 1, 4},
+		{8, UnitRunes, 1, 2},
+		{8, UnitUTF16, 1, 2},
+		{14, UnitBytes, 2, 4},
+		{14, UnitRunes, 2, 1},
+		{14, UnitUTF16, 2, 2},
+		{16, UnitBytes, 3, 0},
+	} {
+		if line, col, err := l.Position(test.Offset, test.Unit); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if line != test.Line || col != test.Col {
+			t.Errorf("test %d: expecting %d:%d, got %d:%d", n+1, test.Line, test.Col, line, col)
+		} else if off, err := l.Offset(line, col, test.Unit); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if off != test.Offset {
+			t.Errorf("test %d: expecting offset %d, got %d", n+1, test.Offset, off)
+		}
+	}
+
+	if _, err := l.Offset(2, 1, UnitUTF16); !errors.Is(err, ErrInvalidColumn) {
+		t.Errorf("expecting ErrInvalidColumn, got %v", err)
+	} else if _, err = l.Offset(0, 4, UnitBytes); !errors.Is(err, ErrInvalidColumn) {
+		t.Errorf("expecting ErrInvalidColumn, got %v", err)
+	} else if _, err = l.Offset(4, 0, UnitBytes); !errors.Is(err, ErrInvalidLine) {
+		t.Errorf("expecting ErrInvalidLine, got %v", err)
+	} else if _, _, err = l.Position(17, UnitBytes); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if _, err = l.LineStart(4); !errors.As(err, new(*OpError)) || !errors.Is(err, ErrInvalidLine) {
+		t.Errorf("expecting OpError wrapping ErrInvalidLine, got %v", err)
+	}
+}
+
+func TestLineIndexUpdate(t *testing.T) {
+	data := []byte("one\ntwo\nthree\n")
+	rw := OpenMem(&data)
+	l := rw.LineIndex()
+
+	check := func(step string) {
+		t.Helper()
+
+		got, _ := l.Lines()
+		l2 := &LineIndex{mem: &rw.WriteMem}
+		expected, _ := l2.Lines()
+
+		if got != expected {
+			t.Errorf("%s: expecting %d lines, got %d", step, expected, got)
+
+			return
+		}
+
+		for n := 0; n < got; n++ {
+			a, _ := l.LineStart(n)
+			b, _ := l2.LineStart(n)
+
+			if a != b {
+				t.Errorf("%s: line %d: expecting start %d, got %d", step, n, b, a)
+			}
+		}
+	}
+
+	check("initial")
+	rw.WriteAt([]byte("\n\n"), 1)
+	check("WriteAt")
+	rw.Seek(0, seekEnd)
+	rw.Write([]byte("four\nfive"))
+	check("Write")
+	rw.WriteByte('\n')
+	check("WriteByte")
+	rw.Truncate(6)
+	check("Truncate")
+	rw.WriteAt([]byte("x\n"), 10)
+	check("WriteAt past end")
+}
+
+func TestSeekLine(t *testing.T) {
+	data := []byte("one\ntwo\nthree")
+	rw := OpenMem(&data)
+
+	if pos, err := rw.SeekLine(1, seekSet); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if pos != 4 {
+		t.Errorf("expecting position 4, got %d", pos)
+	} else if pos, err = rw.SeekLine(1, seekCurr); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if pos != 8 {
+		t.Errorf("expecting position 8, got %d", pos)
+	} else if pos, err = rw.SeekLine(-2, seekEnd); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if pos != 0 {
+		t.Errorf("expecting position 0, got %d", pos)
+	} else if _, err = rw.SeekLine(3, seekSet); !errors.Is(err, ErrInvalidLine) {
+		t.Errorf("expecting ErrInvalidLine, got %v", err)
+	}
+}
+
+func TestLineIndexInsertDelete(t *testing.T) {
+	data := []byte("one\ntwo\nthree\n")
+	rw := OpenMem(&data)
+	l := rw.LineIndex()
+
+	l.Lines()
+	rw.InsertAt(4, []byte("1\n2\n"))
+	rw.DeleteRange(0, 2)
+	rw.Fill(2, 3, '\n')
+
+	l.Lines()
+
+	got := append([]int(nil), l.starts...)
+
+	l.Invalidate()
+	l.Lines()
+
+	if !reflect.DeepEqual(got, l.starts) {
+		t.Errorf("expecting line starts %v, got %v", l.starts, got)
+	}
+}
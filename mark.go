@@ -0,0 +1,223 @@
+package memio
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Mark is an opaque token recording a read position, to which a reader can
+// later be reset.
+//
+// For Buffer, LimitedBuffer and String, a Mark refers to the data consumed
+// after it was made, keeping it in memory for as long as the Mark is retained.
+type Mark struct {
+	pos  int64
+	data []byte
+	str  string
+}
+
+// Marker is implemented by types that can record a read position and later
+// return to it.
+type Marker interface {
+	Mark() Mark
+	ResetTo(Mark) error
+}
+
+// Mark records the current read position.
+func (s *Buffer) Mark() Mark {
+	return Mark{data: *s}
+}
+
+// ResetTo returns the read position to that recorded by the Mark, restoring
+// any bytes read since.
+//
+// Returns ErrInvalidMark if the buffer has been reallocated, by a write, since
+// the Mark was made.
+func (s *Buffer) ResetTo(m Mark) error {
+	data, err := resetSlice(*s, m.data)
+	if err != nil {
+		return err
+	}
+
+	*s = data
+
+	return nil
+}
+
+// Mark records the current read position.
+func (s *LimitedBuffer) Mark() Mark {
+	return Mark{data: *s}
+}
+
+// ResetTo returns the read position to that recorded by the Mark, restoring
+// any bytes read since.
+func (s *LimitedBuffer) ResetTo(m Mark) error {
+	data, err := resetSlice(*s, m.data)
+	if err != nil {
+		return err
+	}
+
+	*s = data
+
+	return nil
+}
+
+// Mark records the current read position.
+func (s *String) Mark() Mark {
+	return Mark{str: string(*s)}
+}
+
+// ResetTo returns the read position to that recorded by the Mark, restoring
+// any bytes read since.
+func (s *String) ResetTo(m Mark) error {
+	if len(*s) > len(m.str) || !sameString(string(*s), m.str) {
+		return ErrInvalidMark
+	}
+
+	*s = String(m.str)
+
+	return nil
+}
+
+// Mark records the current read position.
+func (r ReadMem) Mark() Mark {
+	return Mark{pos: int64(r.pos())}
+}
+
+// ResetTo returns the read position to that recorded by the Mark.
+func (r ReadMem) ResetTo(m Mark) error {
+	_, err := r.Seek(m.pos, seekSet)
+
+	return err
+}
+
+// Mark records the current read position.
+func (s *StringMem) Mark() Mark {
+	return Mark{pos: s.pos}
+}
+
+// ResetTo returns the read position to that recorded by the Mark.
+func (s *StringMem) ResetTo(m Mark) error {
+	_, err := s.Seek(m.pos, seekSet)
+
+	return err
+}
+
+// Mark records the current read position.
+func (b *ReadWriteMem) Mark() Mark {
+	return Mark{pos: int64(b.pos)}
+}
+
+// ResetTo returns the read position to that recorded by the Mark.
+func (b *ReadWriteMem) ResetTo(m Mark) error {
+	if b.data == nil {
+		return opError("resetto", m.pos, ErrClosed)
+	}
+
+	b.pos = int(m.pos)
+
+	return nil
+}
+
+func resetSlice(cur, mark []byte) ([]byte, error) {
+	if cap(cur) > cap(mark) || !sameSlice(cur, mark) {
+		return nil, ErrInvalidMark
+	}
+
+	return mark[:cap(mark)-cap(cur)+len(cur)], nil
+}
+
+// sameSlice reports whether cur ends at the same place in the same backing
+// array as mark, as it will when cur has been produced by consuming from the
+// front of mark.
+//
+// A slice consumed to zero capacity keeps its previous start, so instead must
+// start within mark.
+func sameSlice(cur, mark []byte) bool {
+	if cap(mark) == 0 {
+		return cap(cur) == 0
+	}
+
+	last := &mark[:cap(mark)][cap(mark)-1]
+
+	if cap(cur) > 0 {
+		return &cur[:cap(cur)][cap(cur)-1] == last
+	}
+
+	return within(unsafe.Pointer(unsafe.SliceData(cur)), unsafe.Pointer(unsafe.SliceData(mark)), unsafe.Pointer(last))
+}
+
+// sameString is the string equivalent of sameSlice.
+func sameString(cur, mark string) bool {
+	if len(mark) == 0 {
+		return len(cur) == 0
+	}
+
+	last := unsafe.StringData(mark[len(mark)-1:])
+
+	if len(cur) > 0 {
+		return unsafe.StringData(cur[len(cur)-1:]) == last
+	}
+
+	return within(unsafe.Pointer(unsafe.StringData(cur)), unsafe.Pointer(unsafe.StringData(mark)), unsafe.Pointer(last))
+}
+
+// within reports whether p lies between first and last, inclusive.
+func within(p, first, last unsafe.Pointer) bool {
+	return uintptr(p) >= uintptr(first) && uintptr(p) <= uintptr(last)
+}
+
+// MarkStack manages a stack of nested Marks on a Marker, such as for use in a
+// recursive-descent parser.
+type MarkStack struct {
+	m     Marker
+	marks []Mark
+}
+
+// NewMarkStack creates a new MarkStack for the given Marker.
+func NewMarkStack(m Marker) *MarkStack {
+	return &MarkStack{m: m}
+}
+
+// Push records the current read position on the stack.
+func (m *MarkStack) Push() {
+	m.marks = append(m.marks, m.m.Mark())
+}
+
+// Reset removes the most recent Mark from the stack and returns the read
+// position to it.
+func (m *MarkStack) Reset() error {
+	if len(m.marks) == 0 {
+		return ErrNoMark
+	}
+
+	mark := m.marks[len(m.marks)-1]
+	m.marks[len(m.marks)-1] = Mark{}
+	m.marks = m.marks[:len(m.marks)-1]
+
+	return m.m.ResetTo(mark)
+}
+
+// Release removes the most recent Mark from the stack, keeping the current
+// read position.
+func (m *MarkStack) Release() error {
+	if len(m.marks) == 0 {
+		return ErrNoMark
+	}
+
+	m.marks[len(m.marks)-1] = Mark{}
+	m.marks = m.marks[:len(m.marks)-1]
+
+	return nil
+}
+
+// Depth returns the number of Marks on the stack.
+func (m *MarkStack) Depth() int {
+	return len(m.marks)
+}
+
+// Errors.
+var (
+	ErrInvalidMark = errors.New("mark not valid for this reader")
+	ErrNoMark      = errors.New("no mark on stack")
+)
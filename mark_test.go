@@ -0,0 +1,103 @@
+package memio
+
+import (
+	"io"
+	"testing"
+)
+
+var (
+	_ Marker = new(Buffer)
+	_ Marker = new(LimitedBuffer)
+	_ Marker = new(String)
+	_ Marker = ReadMem{}
+	_ Marker = new(ReadWriteMem)
+)
+
+func TestMark(t *testing.T) {
+	buf := make(Buffer, 0, 32)
+	lb := make(LimitedBuffer, 0, 32)
+	str := String("Hello, World!")
+	data := []byte("Hello, World!")
+
+	buf.WriteString("Hello, World!")
+	lb.WriteString("Hello, World!")
+
+	for n, r := range [...]interface {
+		io.Reader
+		Marker
+	}{
+		&buf,
+		&lb,
+		&str,
+		Open(data),
+		OpenMem(&data),
+	} {
+		p := make([]byte, 5)
+		s := NewMarkStack(r)
+
+		r.Read(p[:2])
+		s.Push()
+		r.Read(p[:3])
+		s.Push()
+		r.Read(p)
+
+		if string(p) != ", Wor" {
+			t.Errorf("test %d: expecting %q, got %q", n+1, ", Wor", p)
+		} else if err := s.Reset(); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if r.Read(p); string(p) != ", Wor" {
+			t.Errorf("test %d: expecting %q, got %q", n+1, ", Wor", p)
+		} else if err = s.Reset(); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if r.Read(p); string(p) != "llo, " {
+			t.Errorf("test %d: expecting %q, got %q", n+1, "llo, ", p)
+		} else if err = s.Reset(); err != ErrNoMark {
+			t.Errorf("test %d: expecting ErrNoMark, got %v", n+1, err)
+		}
+	}
+}
+
+func TestMarkInvalid(t *testing.T) {
+	buf := make(Buffer, 0, 4)
+
+	buf.WriteString("abcd")
+
+	m := buf.Mark()
+
+	buf.ReadByte()
+	buf.WriteString("efgh")
+
+	if err := buf.ResetTo(m); err != ErrInvalidMark {
+		t.Errorf("expecting ErrInvalidMark, got %v", err)
+	}
+
+	str := String("abc")
+	m = str.Mark()
+	str = "xyz"
+
+	if err := str.ResetTo(m); err != ErrInvalidMark {
+		t.Errorf("expecting ErrInvalidMark, got %v", err)
+	}
+}
+
+func TestMarkConsumed(t *testing.T) {
+	buf := Buffer("abc")
+	m := buf.Mark()
+	str := String("abc")
+	ms := str.Mark()
+
+	buf.Read(make([]byte, 3))
+	str.Read(make([]byte, 3))
+
+	if err := buf.ResetTo(m); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf) != "abc" {
+		t.Errorf("expecting %q, got %q", "abc", buf)
+	} else if err = str.ResetTo(ms); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if str != "abc" {
+		t.Errorf("expecting %q, got %q", "abc", str)
+	} else if buf = nil; buf.ResetTo(m) != ErrInvalidMark {
+		t.Errorf("expecting ErrInvalidMark")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"os"
 )
 
 const (
@@ -13,6 +14,11 @@ const (
 	seekEnd
 )
 
+const (
+	minRead     = 512
+	scratchSize = 32768
+)
+
 // ErrClosed is an error returned when trying to perform an operation after using Close().
 var ErrClosed = errors.New("operation not permitted when closed")
 
@@ -33,36 +39,83 @@ func (ReadMem) Close() error {
 	return nil
 }
 
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (r ReadMem) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	}
+
+	return r.Reader.ReadAt(p, off)
+}
+
+// Seek is an implementation of the io.Seeker interface.
+func (r ReadMem) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += int64(r.pos())
+	case seekEnd:
+		offset += r.Reader.Size()
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	return r.Reader.Seek(offset, seekSet)
+}
+
 // Peek reads the next n bytes without advancing the position.
 func (r ReadMem) Peek(n int) ([]byte, error) {
-	pos, _ := r.Seek(0, seekCurr)
+	pos := r.pos()
+
+	if n < 0 {
+		return nil, opError("peek", int64(pos), ErrInvalidCount)
+	}
+
 	buf := make([]byte, n)
-	_, err := r.ReadAt(buf, pos)
+	m, err := r.ReadAt(buf, int64(pos))
 
-	return buf, err
+	return buf[:m], err
 }
 
 // WriteMem holds a pointer to a byte slice and allows numerous io interfaces
 // to be used with it.
 type WriteMem struct {
-	data *[]byte
-	pos  int
+	data  *[]byte
+	pos   int
+	align int
+	alloc allocator
+	index *LineIndex
+}
+
+// allocator provides memory for a WriteMem from outside of the Go heap.
+type allocator interface {
+	realloc(data []byte, size int) ([]byte, error)
 }
 
 // Create uses a byte slice for writing. Implements io.Writer, io.Seeker,
 // io.Closer, io.WriterAt, io.ByteWriter and io.ReaderFrom.
 func Create(data *[]byte) *WriteMem {
-	return &WriteMem{data, 0}
+	return &WriteMem{data: data}
 }
 
 // Write is an implementation of the io.Writer interface.
 func (b *WriteMem) Write(p []byte) (int, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("write", int64(b.pos), ErrClosed)
+	} else if !validRange(int64(b.pos), int64(len(p))) {
+		return 0, opError("write", int64(b.pos), ErrInvalidOffset)
+	}
+
+	if err := b.setSize(b.pos + len(p)); err != nil {
+		return 0, opError("write", int64(b.pos), err)
 	}
 
-	b.setSize(b.pos + len(p))
 	n := copy((*b.data)[b.pos:], p)
+	b.index.update(b.pos, n, n)
 	b.pos += n
 
 	return n, nil
@@ -71,48 +124,98 @@ func (b *WriteMem) Write(p []byte) (int, error) {
 // WriteAt is an implementation of the io.WriterAt interface.
 func (b *WriteMem) WriteAt(p []byte, off int64) (int, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("writeat", off, ErrClosed)
+	} else if !validRange(off, int64(len(p))) {
+		return 0, opError("writeat", off, ErrInvalidOffset)
+	} else if len(p) == 0 {
+		return 0, nil
 	}
 
-	b.setSize(int(off) + len(p))
+	if err := b.setSize(int(off) + len(p)); err != nil {
+		return 0, opError("writeat", off, err)
+	}
 
-	return copy((*b.data)[off:], p), nil
+	n := copy((*b.data)[off:], p)
+	b.index.update(int(off), n, n)
+
+	return n, nil
 }
 
 // WriteByte is an implementation of the io.WriteByte interface.
 func (b *WriteMem) WriteByte(c byte) error {
 	if b.data == nil {
-		return ErrClosed
+		return opError("writebyte", int64(b.pos), ErrClosed)
+	} else if !validRange(int64(b.pos), 1) {
+		return opError("writebyte", int64(b.pos), ErrInvalidOffset)
+	}
+
+	if err := b.setSize(b.pos + 1); err != nil {
+		return opError("writebyte", int64(b.pos), err)
 	}
 
-	b.setSize(b.pos + 1)
 	(*b.data)[b.pos] = c
+	b.index.update(b.pos, 1, 1)
 	b.pos++
 
 	return nil
 }
 
 // ReadFrom is an implementation of the io.ReaderFrom interface.
+//
+// When the size of the remaining data in the reader can be determined, the
+// byte slice is grown once, in advance, to accommodate it. Data read over
+// existing bytes is read via a separate buffer, so that the Reader cannot
+// disturb those beyond it.
 func (b *WriteMem) ReadFrom(f io.Reader) (int64, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("readfrom", int64(b.pos), ErrClosed)
+	}
+
+	if b.pos > len(*b.data) {
+		if err := b.setSize(b.pos); err != nil {
+			return 0, opError("readfrom", int64(b.pos), err)
+		}
+	}
+
+	if n := readSize(f, b.pos); n > 0 {
+		if err := b.reserve(int(min(int64(b.pos)+int64(n), maxAlloc))); err != nil {
+			return 0, opError("readfrom", int64(b.pos), err)
+		}
 	}
 
 	var (
-		c   int64
-		n   int
-		err error
+		c       int64
+		scratch []byte
 	)
 
-	buf := make([]byte, 1024)
-
 	for {
-		n, err = f.Read(buf)
+		var (
+			n   int
+			err error
+		)
+
+		if l := len(*b.data); b.pos < l {
+			if scratch == nil {
+				scratch = make([]byte, min(l-b.pos, scratchSize))
+			}
+
+			n, err = f.Read(scratch[:min(l-b.pos, len(scratch))])
+			copy((*b.data)[b.pos:], scratch[:n])
+		} else {
+			if cap(*b.data)-b.pos < max(b.align, 1) {
+				if err := b.grow(b.pos + max(b.align, 1)); err != nil {
+					return c, opError("readfrom", int64(b.pos), err)
+				}
+			}
+
+			n, err = f.Read(alignedChunk((*b.data)[b.pos:cap(*b.data)], b.align))
+			*b.data = (*b.data)[:b.pos+n]
+		}
+
 		if n > 0 {
 			c += int64(n)
 
-			b.setSize(b.pos + n)
-			copy((*b.data)[b.pos:], buf[:n])
+			b.index.update(b.pos, n, n)
 
 			b.pos += n
 		}
@@ -122,33 +225,34 @@ func (b *WriteMem) ReadFrom(f io.Reader) (int64, error) {
 				err = nil
 			}
 
-			break
+			return c, err
 		}
 	}
-
-	return c, err
 }
 
 // Seek is an implementation of the io.Seeker interface.
 func (b *WriteMem) Seek(offset int64, whence int) (int64, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("seek", offset, ErrClosed)
 	}
 
 	switch whence {
 	case seekSet:
-		b.pos = int(offset)
 	case seekCurr:
-		b.pos += int(offset)
+		offset += int64(b.pos)
 	case seekEnd:
-		b.pos = len(*b.data) + int(offset)
+		offset += int64(len(*b.data))
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
 	}
 
-	if b.pos < 0 {
-		b.pos = 0
+	if offset < 0 || offset > maxInt {
+		return 0, opError("seek", offset, ErrInvalidOffset)
 	}
 
-	return int64(b.pos), nil
+	b.pos = int(offset)
+
+	return offset, nil
 }
 
 // Close is an implementation of the io.Closer interface.
@@ -158,36 +262,189 @@ func (b *WriteMem) Close() error {
 	return nil
 }
 
-func (b *WriteMem) setSize(end int) {
-	if end > len(*b.data) {
-		if end < cap(*b.data) {
-			*b.data = (*b.data)[:end]
-		} else {
-			var newData []byte
+// setSize extends the byte slice to the given length, zeroing any newly
+// exposed bytes that may hold stale data from its spare capacity.
+func (b *WriteMem) setSize(end int) error {
+	if l := len(*b.data); end > l {
+		if err := b.grow(end); err != nil {
+			return err
+		}
 
-			if len(*b.data) < 512 {
-				newData = make([]byte, end, end<<1)
-			} else {
-				newData = make([]byte, end, end+(end>>2))
-			}
+		*b.data = (*b.data)[:end]
 
-			copy(newData, *b.data)
+		clear((*b.data)[l:end])
+	}
 
-			*b.data = newData
+	return nil
+}
+
+func (b *WriteMem) grow(end int) error {
+	if end <= cap(*b.data) {
+		return nil
+	} else if end < 0 || int64(end) > maxAlloc {
+		return ErrTooLarge
+	} else if len(*b.data) < 512 {
+		return b.reserve(int(min(int64(end)<<1, maxAlloc)))
+	}
+
+	return b.reserve(int(min(int64(end)+int64(end>>2), maxAlloc)))
+}
+
+func (b *WriteMem) reserve(size int) error {
+	if size <= cap(*b.data) {
+		return nil
+	} else if int64(size) > maxAlloc {
+		return ErrTooLarge
+	} else if b.alloc != nil {
+		data, err := b.alloc.realloc(*b.data, size)
+		if err != nil {
+			return err
 		}
+
+		*b.data = data
+
+		return nil
 	}
+
+	newData := alignedSlice(len(*b.data), size, b.align)
+
+	copy(newData, *b.data)
+
+	*b.data = newData
+
+	return nil
 }
 
 // Truncate changes the length of the byte slice to the given amount.
 func (b *WriteMem) Truncate(s int64) error {
+	if b.data == nil {
+		return opError("truncate", s, ErrClosed)
+	} else if !validRange(s, 0) {
+		return opError("truncate", s, ErrInvalidOffset)
+	}
+
 	if l := int64(len(*b.data)); l > s {
-		copy((*b.data)[s:], make([]byte, l-s))
+		clear((*b.data)[s:])
 
 		*b.data = (*b.data)[:s]
+
+		b.index.update(int(s), int(l-s), 0)
 	} else if l < s {
-		b.setSize(int(s))
+		if err := b.setSize(int(s)); err != nil {
+			return opError("truncate", s, err)
+		}
+	}
+
+	return nil
+}
+
+// InsertAt inserts the given bytes at the given offset, moving any following
+// bytes forward. A position after the offset is moved forward by the number of
+// bytes inserted.
+func (b *WriteMem) InsertAt(off int64, p []byte) (int, error) {
+	if b.data == nil {
+		return 0, opError("insert", off, ErrClosed)
+	} else if !validRange(off, int64(len(p))) || int64(len(*b.data)) > maxInt-int64(len(p)) {
+		return 0, opError("insert", off, ErrInvalidOffset)
+	}
+
+	o := int(off)
+	l := len(*b.data)
+
+	if o > l {
+		l = o
+	}
+
+	if err := b.setSize(l + len(p)); err != nil {
+		return 0, opError("insert", off, err)
+	}
+
+	copy((*b.data)[o+len(p):], (*b.data)[o:l])
+	copy((*b.data)[o:], p)
+	b.index.update(o, 0, len(p))
+
+	if b.pos > o {
+		b.pos += len(p)
+	}
+
+	return len(p), nil
+}
+
+// DeleteRange removes n bytes starting at the given offset, moving any
+// following bytes back. A position after the removed bytes is moved back by
+// the number of bytes removed, and a position within them is moved to the
+// offset.
+func (b *WriteMem) DeleteRange(off, n int64) error {
+	if b.data == nil {
+		return opError("delete", off, ErrClosed)
+	} else if l := int64(len(*b.data)); off < 0 || off > l || n < 0 {
+		return opError("delete", off, ErrInvalidOffset)
+	} else if n > l-off {
+		n = l - off
+	}
+
+	o, m := int(off), int(n)
+	l := len(*b.data) - m
+
+	copy((*b.data)[o:], (*b.data)[o+m:])
+	clear((*b.data)[l : l+m])
+
+	*b.data = (*b.data)[:l]
+
+	b.index.update(o, m, 0)
+
+	if b.pos > o+m {
+		b.pos -= m
+	} else if b.pos > o {
+		b.pos = o
+	}
+
+	return nil
+}
+
+// Move copies n bytes from the src offset to the dst offset, correctly handling
+// overlapping regions. The byte slice will be grown if the destination extends
+// beyond its end.
+func (b *WriteMem) Move(dst, src, n int64) error {
+	if b.data == nil {
+		return opError("move", dst, ErrClosed)
+	} else if !validRange(src, n) || src+n > int64(len(*b.data)) {
+		return opError("move", src, ErrInvalidOffset)
+	} else if !validRange(dst, n) {
+		return opError("move", dst, ErrInvalidOffset)
+	}
+
+	if err := b.setSize(int(dst + n)); err != nil {
+		return opError("move", dst, err)
 	}
 
+	copy((*b.data)[dst:dst+n], (*b.data)[src:src+n])
+	b.index.update(int(dst), int(n), int(n))
+
+	return nil
+}
+
+// Fill sets n bytes, starting at the given offset, to the given byte. The byte
+// slice will be grown if the filled region extends beyond its end.
+func (b *WriteMem) Fill(off, n int64, c byte) error {
+	if b.data == nil {
+		return opError("fill", off, ErrClosed)
+	} else if !validRange(off, n) {
+		return opError("fill", off, ErrInvalidOffset)
+	}
+
+	if err := b.setSize(int(off + n)); err != nil {
+		return opError("fill", off, err)
+	}
+
+	data := (*b.data)[off : off+n]
+
+	for i := range data {
+		data[i] = c
+	}
+
+	b.index.update(int(off), int(n), int(n))
+
 	return nil
 }
 
@@ -195,3 +452,53 @@ func (b *WriteMem) Truncate(s int64) error {
 func (b *WriteMem) WriteString(s string) (int, error) {
 	return b.Write([]byte(s))
 }
+
+// readSize returns the number of bytes, beyond the given length, to reserve
+// in order to read the remaining data from the reader in one go, or zero when
+// that cannot be determined.
+func readSize(r io.Reader, l int) int {
+	if hint := sizeHint(r); hint > 0 {
+		return int(min(hint, maxInt-int64(l)-1)) + 1
+	}
+
+	return 0
+}
+
+// sizeHint attempts to determine the number of bytes remaining to be read from
+// the given reader, returning -1 when it cannot.
+func sizeHint(r io.Reader) int64 {
+	switch r := r.(type) {
+	case *io.LimitedReader:
+		h := sizeHint(r.R)
+		if h < 0 {
+			return -1
+		}
+
+		return max(min(h, r.N), 0)
+	case *io.SectionReader:
+		pos, err := r.Seek(0, seekCurr)
+		if err != nil {
+			return -1
+		}
+
+		return r.Size() - pos
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case *os.File:
+		fi, err := r.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return -1
+		}
+
+		pos, err := r.Seek(0, seekCurr)
+		if err != nil || pos > fi.Size() {
+			return -1
+		}
+
+		return fi.Size() - pos
+	case interface{ Size() int64 }:
+		return r.Size()
+	}
+
+	return -1
+}
@@ -1,7 +1,9 @@
 package memio
 
 import (
+	"errors"
 	"io"
+	"strings"
 	"testing"
 )
 
@@ -139,9 +141,9 @@ func TestWrite(t *testing.T) {
 		t.Errorf("expecting %q, got %q", "Edwardo", string(data))
 	} else if err = writer.Close(); err != nil {
 		t.Errorf("unexpected error: %s", err)
-	} else if _, err = writer.Seek(0, 0); err != ErrClosed {
+	} else if _, err = writer.Seek(0, 0); !errors.Is(err, ErrClosed) {
 		t.Errorf("expecting close error")
-	} else if _, err := writer.Write([]byte("Beep")); err != ErrClosed {
+	} else if _, err := writer.Write([]byte("Beep")); !errors.Is(err, ErrClosed) {
 		t.Errorf("expecting close error")
 	}
 }
@@ -249,3 +251,184 @@ func TestTruncate(t *testing.T) {
 		}
 	}
 }
+
+func TestStaleCapacity(t *testing.T) {
+	data := []byte("0123456789")[:2]
+	w := Create(&data)
+
+	if err := w.Truncate(4); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "01\x00\x00" {
+		t.Errorf("expecting %q, got %q", "01\x00\x00", data)
+	} else if _, err = w.WriteAt([]byte("X"), 7); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "01\x00\x00\x00\x00\x00X" {
+		t.Errorf("expecting %q, got %q", "01\x00\x00\x00\x00\x00X", data)
+	}
+}
+
+func TestInsertDelete(t *testing.T) {
+	data := []byte("Hello World")
+	w := Create(&data)
+
+	w.Seek(6, seekSet)
+
+	if _, err := w.InsertAt(5, []byte(",")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "Hello, World" {
+		t.Errorf("expecting %q, got %q", "Hello, World", data)
+	} else if w.pos != 7 {
+		t.Errorf("expecting position 7, got %d", w.pos)
+	} else if err = w.DeleteRange(0, 7); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "World" {
+		t.Errorf("expecting %q, got %q", "World", data)
+	} else if w.pos != 0 {
+		t.Errorf("expecting position 0, got %d", w.pos)
+	} else if _, err = w.InsertAt(7, []byte("!")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "World\x00\x00!" {
+		t.Errorf("expecting %q, got %q", "World\x00\x00!", data)
+	} else if err = w.DeleteRange(5, 10); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "World" {
+		t.Errorf("expecting %q, got %q", "World", data)
+	} else if err = w.DeleteRange(6, 1); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	}
+}
+
+func TestMoveFill(t *testing.T) {
+	data := []byte("abcdef")
+	w := Create(&data)
+
+	if err := w.Move(2, 0, 4); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "ababcd" {
+		t.Errorf("expecting %q, got %q", "ababcd", data)
+	} else if err = w.Move(4, 0, 4); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "abababab" {
+		t.Errorf("expecting %q, got %q", "abababab", data)
+	} else if err = w.Fill(6, 4, 'z'); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(data) != "abababzzzz" {
+		t.Errorf("expecting %q, got %q", "abababzzzz", data)
+	} else if err = w.Move(0, 8, 3); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	}
+}
+
+func TestSizeHint(t *testing.T) {
+	data := []byte("Hello, World!")
+	r := Open(data)
+
+	r.Seek(7, seekSet)
+
+	for n, test := range [...]struct {
+		Reader   io.Reader
+		Expected int64
+	}{
+		{r, 6},
+		{io.LimitReader(r, 3), 3},
+		{io.LimitReader(r, 10), 6},
+		{io.NewSectionReader(r, 2, 8), 8},
+		{struct{ io.Reader }{r}, -1},
+		{io.LimitReader(struct{ io.Reader }{r}, 1<<62), -1},
+		{io.LimitReader(r, -1), 0},
+	} {
+		if h := sizeHint(test.Reader); h != test.Expected {
+			t.Errorf("test %d: expecting hint %d, got %d", n+1, test.Expected, h)
+		}
+	}
+}
+
+func TestReadFromSized(t *testing.T) {
+	data := []byte("Hello")
+	w := Create(&data)
+
+	w.Seek(3, seekSet)
+
+	if n, err := w.ReadFrom(Open([]byte("p me!"))); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n != 5 {
+		t.Errorf("expecting to read 5 bytes, read %d", n)
+	} else if string(data) != "Help me!" {
+		t.Errorf("expecting %q, got %q", "Help me!", data)
+	} else if cap(data) != 9 {
+		t.Errorf("expecting capacity 9, got %d", cap(data))
+	}
+
+	var buf Buffer
+
+	if n, err := w.ReadFrom(io.LimitReader(struct{ io.Reader }{strings.NewReader("!!")}, 1<<62)); n != 2 || err != nil {
+		t.Errorf("expecting to read 2 bytes with nil error, read %d with %v", n, err)
+	} else if string(data) != "Help me!!!" {
+		t.Errorf("expecting %q, got %q", "Help me!!!", data)
+	} else if n, err = buf.ReadFrom(io.LimitReader(struct{ io.Reader }{strings.NewReader("hello")}, 1<<62)); n != 5 || err != nil {
+		t.Errorf("expecting to read 5 bytes with nil error, read %d with %v", n, err)
+	} else if string(buf) != "hello" {
+		t.Errorf("expecting %q, got %q", "hello", buf)
+	}
+}
+
+type scribbleReader struct {
+	data string
+}
+
+func (s *scribbleReader) Read(p []byte) (int, error) {
+	if s.data == "" {
+		return 0, io.EOF
+	}
+
+	n := copy(p, s.data)
+	s.data = s.data[n:]
+
+	for i := n; i < len(p); i++ {
+		p[i] = '#'
+	}
+
+	return n, nil
+}
+
+func TestReadFromOverwrite(t *testing.T) {
+	data := []byte("0123456789")
+	w := OpenMem(&data)
+	l := w.LineIndex()
+
+	w.Seek(2, seekSet)
+
+	if _, err := l.Lines(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err := w.ReadFrom(&scribbleReader{data: "ab"}); n != 2 || err != nil {
+		t.Errorf("expecting to read 2 bytes with nil error, read %d with %v", n, err)
+	} else if string(data) != "01ab456789" {
+		t.Errorf("expecting %q, got %q", "01ab456789", data)
+	} else if n, err = w.ReadFrom(&scribbleReader{data: "cdefgh\nij"}); n != 9 || err != nil {
+		t.Errorf("expecting to read 9 bytes with nil error, read %d with %v", n, err)
+	} else if string(data) != "01abcdefgh\nij" {
+		t.Errorf("expecting %q, got %q", "01abcdefgh\nij", data)
+	} else if lines, _ := l.Lines(); lines != 2 {
+		t.Errorf("expecting 2 lines, got %d", lines)
+	}
+}
+
+func TestTooLarge(t *testing.T) {
+	var data []byte
+
+	w := Create(&data)
+
+	if _, err := w.WriteAt([]byte("a"), 1<<62); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	} else if err = w.Truncate(1 << 62); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	} else if err = w.Fill(1<<62, 1, 'a'); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	} else if _, err = w.TailSection(1<<62, -1).Write([]byte("a")); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expecting ErrTooLarge, got %v", err)
+	} else if !errors.As(err, new(*OpError)) {
+		t.Errorf("expecting *OpError, got %T", err)
+	} else if len(data) != 0 {
+		t.Errorf("expecting no data to be written, got %d bytes", len(data))
+	}
+}
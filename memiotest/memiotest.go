@@ -0,0 +1,109 @@
+// Package memiotest provides helpers that test implementations of the io
+// interfaces against the documented io contracts and a reference model, using
+// randomised sequences of operations.
+//
+// When a sequence of operations fails, it is reduced to a minimal sequence that
+// reproduces the failure, which is then reported.
+package memiotest // import "vimagination.zapto.org/memio/memiotest"
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	iterations = 100
+	maxOps     = 50
+	maxData    = 1024
+)
+
+// run generates random sequences of operations with gen and runs them with
+// check, reporting the minimal failing sequence of the first failure.
+func run[O fmt.Stringer](t testing.TB, gen func(r *rand.Rand, size int) O, check func(data []byte, ops []O) error) {
+	t.Helper()
+
+	for seed := uint64(1); seed <= iterations; seed++ {
+		r := rand.New(rand.NewPCG(seed, seed))
+		data := randomBytes(r, r.IntN(maxData))
+		ops := make([]O, 1+r.IntN(maxOps))
+
+		for n := range ops {
+			ops[n] = gen(r, len(data))
+		}
+
+		if err := safeCheck(check, data, ops); err != nil {
+			ops = shrink(ops, func(ops []O) bool {
+				return safeCheck(check, data, ops) != nil
+			})
+
+			t.Errorf("seed %d, data length %d: %s\nminimal reproduction:\n\t%s", seed, len(data), safeCheck(check, data, ops), join(ops))
+
+			return
+		}
+	}
+}
+
+// safeCheck runs the check, converting any panic into an error.
+func safeCheck[O any](check func(data []byte, ops []O) error, data []byte, ops []O) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return check(data, ops)
+}
+
+// shrink repeatedly removes single operations from the sequence for as long as
+// the resulting sequence still fails.
+func shrink[O any](ops []O, fails func([]O) bool) []O {
+	for changed := true; changed; {
+		changed = false
+
+		for n := 0; n < len(ops); n++ {
+			try := slices.Delete(slices.Clone(ops), n, n+1)
+
+			if fails(try) {
+				ops = try
+				changed = true
+				n--
+			}
+		}
+	}
+
+	return ops
+}
+
+func join[O fmt.Stringer](ops []O) string {
+	strs := make([]string, len(ops))
+
+	for n, op := range ops {
+		strs[n] = op.String()
+	}
+
+	return strings.Join(strs, "\n\t")
+}
+
+func randomBytes(r *rand.Rand, n int) []byte {
+	p := make([]byte, n)
+
+	for i := range p {
+		p[i] = byte(r.Uint32())
+	}
+
+	return p
+}
+
+// randomText returns printable bytes, so that reproductions are readable.
+func randomText(r *rand.Rand, n int) []byte {
+	p := make([]byte, n)
+
+	for i := range p {
+		p[i] = 'a' + byte(r.IntN(26))
+	}
+
+	return p
+}
@@ -0,0 +1,204 @@
+package memiotest_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"vimagination.zapto.org/memio"
+	"vimagination.zapto.org/memio/memiotest"
+)
+
+func TestBuffer(t *testing.T) {
+	newBuffer := func(data []byte) *memio.Buffer {
+		b := memio.Buffer(data)
+
+		return &b
+	}
+
+	memiotest.TestReader(t, func(data []byte) io.Reader { return newBuffer(data) })
+	memiotest.TestReaderAt(t, func(data []byte) io.ReaderAt { return newBuffer(data) })
+	memiotest.TestPeeker(t, func(data []byte) memiotest.Peeker { return newBuffer(data) })
+	memiotest.TestWriterTo(t, func(data []byte) io.WriterTo { return newBuffer(data) })
+	memiotest.TestWriter(t, func() (io.Writer, func() []byte) {
+		b := newBuffer(nil)
+
+		return b, func() []byte { return *b }
+	})
+	memiotest.TestWriterAt(t, func() (io.WriterAt, func() []byte) {
+		b := newBuffer(nil)
+
+		return b, func() []byte { return *b }
+	})
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	newLimitedBuffer := func(data []byte) *memio.LimitedBuffer {
+		b := memio.LimitedBuffer(data)
+
+		return &b
+	}
+
+	memiotest.TestReader(t, func(data []byte) io.Reader { return newLimitedBuffer(data) })
+	memiotest.TestReaderAt(t, func(data []byte) io.ReaderAt { return newLimitedBuffer(data) })
+	memiotest.TestPeeker(t, func(data []byte) memiotest.Peeker { return newLimitedBuffer(data) })
+	memiotest.TestWriterTo(t, func(data []byte) io.WriterTo { return newLimitedBuffer(data) })
+	memiotest.TestWriter(t, func() (io.Writer, func() []byte) {
+		b := newLimitedBuffer(make([]byte, 0, 512))
+
+		return b, func() []byte { return *b }
+	})
+	memiotest.TestWriterAt(t, func() (io.WriterAt, func() []byte) {
+		b := newLimitedBuffer(make([]byte, 0, 256))
+
+		return b, func() []byte { return *b }
+	})
+}
+
+func TestBoundedBuffer(t *testing.T) {
+	memiotest.TestWriter(t, func() (io.Writer, func() []byte) {
+		b := memio.NewBoundedBuffer(0, 1<<20)
+
+		return b, func() []byte { return b.Buffer }
+	})
+	memiotest.TestWriterAt(t, func() (io.WriterAt, func() []byte) {
+		b := memio.NewBoundedBuffer(0, 1<<20)
+
+		return b, func() []byte { return b.Buffer }
+	})
+}
+
+func TestOverflowBuffer(t *testing.T) {
+	memiotest.TestWriter(t, func() (io.Writer, func() []byte) {
+		b := memio.NewOverflowBuffer(512, memio.OverflowError)
+
+		return b, func() []byte { return b.LimitedBuffer }
+	})
+	memiotest.TestWriterAt(t, func() (io.WriterAt, func() []byte) {
+		b := memio.NewOverflowBuffer(256, memio.OverflowError)
+
+		return b, func() []byte { return b.LimitedBuffer }
+	})
+}
+
+func TestString(t *testing.T) {
+	newString := func(data []byte) *memio.String {
+		s := memio.String(data)
+
+		return &s
+	}
+
+	memiotest.TestReader(t, func(data []byte) io.Reader { return newString(data) })
+	memiotest.TestPeeker(t, func(data []byte) memiotest.Peeker { return newString(data) })
+	memiotest.TestWriterTo(t, func(data []byte) io.WriterTo { return newString(data) })
+}
+
+func TestStringMem(t *testing.T) {
+	memiotest.TestReader(t, func(data []byte) io.Reader { return memio.OpenString(string(data)) })
+	memiotest.TestReaderAt(t, func(data []byte) io.ReaderAt { return memio.OpenString(string(data)) })
+	memiotest.TestSeeker(t, func(data []byte) io.ReadSeeker { return memio.OpenString(string(data)) })
+	memiotest.TestPeeker(t, func(data []byte) memiotest.Peeker { return memio.OpenString(string(data)) })
+	memiotest.TestWriterTo(t, func(data []byte) io.WriterTo { return memio.OpenString(string(data)) })
+	memiotest.TestByteScanner(t, func(data []byte) io.ByteScanner { return memio.OpenString(string(data)) })
+}
+
+func TestReadMem(t *testing.T) {
+	memiotest.TestReader(t, func(data []byte) io.Reader { return memio.Open(data) })
+	memiotest.TestReaderAt(t, func(data []byte) io.ReaderAt { return memio.Open(data) })
+	memiotest.TestSeeker(t, func(data []byte) io.ReadSeeker { return memio.Open(data) })
+	memiotest.TestPeeker(t, func(data []byte) memiotest.Peeker { return memio.Open(data) })
+	memiotest.TestWriterTo(t, func(data []byte) io.WriterTo { return memio.Open(data) })
+	memiotest.TestByteScanner(t, func(data []byte) io.ByteScanner { return memio.Open(data) })
+}
+
+func TestWriteMem(t *testing.T) {
+	memiotest.TestWriter(t, func() (io.Writer, func() []byte) {
+		var data []byte
+
+		return memio.Create(&data), func() []byte { return data }
+	})
+	memiotest.TestWriterAt(t, func() (io.WriterAt, func() []byte) {
+		var data []byte
+
+		return memio.Create(&data), func() []byte { return data }
+	})
+}
+
+func TestReadWriteMem(t *testing.T) {
+	memiotest.TestReader(t, func(data []byte) io.Reader { return memio.OpenMem(&data) })
+	memiotest.TestReaderAt(t, func(data []byte) io.ReaderAt { return memio.OpenMem(&data) })
+	memiotest.TestSeeker(t, func(data []byte) io.ReadSeeker { return memio.OpenMem(&data) })
+	memiotest.TestPeeker(t, func(data []byte) memiotest.Peeker { return memio.OpenMem(&data) })
+	memiotest.TestWriterTo(t, func(data []byte) io.WriterTo { return memio.OpenMem(&data) })
+	memiotest.TestByteScanner(t, func(data []byte) io.ByteScanner { return memio.OpenMem(&data) })
+	memiotest.TestWriter(t, func() (io.Writer, func() []byte) {
+		var data []byte
+
+		return memio.OpenMem(&data), func() []byte { return data }
+	})
+	memiotest.TestWriterAt(t, func() (io.WriterAt, func() []byte) {
+		var data []byte
+
+		return memio.OpenMem(&data), func() []byte { return data }
+	})
+}
+
+func TestFIFOMem(t *testing.T) {
+	memiotest.TestReader(t, func(data []byte) io.Reader { return memio.OpenFIFO(&data) })
+	memiotest.TestReaderAt(t, func(data []byte) io.ReaderAt { return memio.OpenFIFO(&data) })
+	memiotest.TestSeeker(t, func(data []byte) io.ReadSeeker { return memio.OpenFIFO(&data) })
+	memiotest.TestPeeker(t, func(data []byte) memiotest.Peeker { return memio.OpenFIFO(&data) })
+	memiotest.TestWriterTo(t, func(data []byte) io.WriterTo { return memio.OpenFIFO(&data) })
+	memiotest.TestByteScanner(t, func(data []byte) io.ByteScanner { return memio.OpenFIFO(&data) })
+	memiotest.TestWriter(t, func() (io.Writer, func() []byte) {
+		var data []byte
+
+		return memio.OpenFIFO(&data), func() []byte { return data }
+	})
+	memiotest.TestWriterAt(t, func() (io.WriterAt, func() []byte) {
+		var data []byte
+
+		return memio.OpenFIFO(&data), func() []byte { return data }
+	})
+}
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+type earlyEOF struct {
+	io.Reader
+}
+
+func (e earlyEOF) Read(p []byte) (int, error) {
+	n, err := e.Reader.Read(p)
+	if n > 0 && err == nil {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+func TestFailure(t *testing.T) {
+	r := &recorder{TB: t}
+
+	memiotest.TestReader(r, func(data []byte) io.Reader {
+		return earlyEOF{memio.Open(data)}
+	})
+
+	if len(r.errors) != 1 {
+		t.Fatalf("expecting 1 error, got %d", len(r.errors))
+	} else if !strings.Contains(r.errors[0], "io.EOF with") {
+		t.Errorf("expecting early EOF error, got: %s", r.errors[0])
+	} else if _, repro, _ := strings.Cut(r.errors[0], "minimal reproduction:\n\t"); strings.Count(repro, "\n") != 0 {
+		t.Errorf("expecting a single operation reproduction, got: %s", repro)
+	}
+}
@@ -0,0 +1,149 @@
+package memiotest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// Peeker is the interface tested by TestPeeker.
+type Peeker interface {
+	io.Reader
+	Peek(n int) ([]byte, error)
+}
+
+type peekOp struct {
+	peek bool
+	n    int
+}
+
+func (p peekOp) String() string {
+	if !p.peek {
+		return readOp(p.n).String()
+	}
+
+	return fmt.Sprintf("Peek(%d)", p.n)
+}
+
+// TestPeeker tests that the Peeker returned by newPeeker returns the upcoming
+// data without advancing the read position.
+func TestPeeker(t testing.TB, newPeeker func(data []byte) Peeker) {
+	t.Helper()
+
+	run(t, func(r *rand.Rand, size int) peekOp {
+		return peekOp{peek: r.IntN(2) == 0, n: genRead(r, size)}
+	}, func(data []byte, ops []peekOp) error {
+		model := bytes.NewReader(data)
+		p := newPeeker(slices.Clone(data))
+
+		for _, op := range ops {
+			if err := checkPeek(p, model, data, op); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func checkPeek(p Peeker, model *bytes.Reader, data []byte, op peekOp) error {
+	if !op.peek {
+		return checkRead(p, model, op.n)
+	}
+
+	buf, err := p.Peek(op.n)
+	pos := len(data) - model.Len()
+	expected := data[pos:][:min(op.n, model.Len())]
+
+	if len(buf) < op.n && err == nil {
+		return fmt.Errorf("peeked %d bytes, expecting %d, with nil error", len(buf), op.n)
+	} else if err != nil && err != io.EOF {
+		return fmt.Errorf("unexpected error: %w", err)
+	} else if !bytes.Equal(buf, expected) {
+		return fmt.Errorf("peeked %q, expecting %q", buf, expected)
+	}
+
+	return nil
+}
+
+type scanOp bool
+
+func (s scanOp) String() string {
+	if s {
+		return "UnreadByte()"
+	}
+
+	return "ReadByte()"
+}
+
+// TestByteScanner tests that the io.ByteScanner returned by newScanner
+// satisfies the io.ByteScanner contract and reads the data it was given.
+func TestByteScanner(t testing.TB, newScanner func(data []byte) io.ByteScanner) {
+	t.Helper()
+
+	run(t, func(r *rand.Rand, _ int) scanOp {
+		return r.IntN(3) == 0
+	}, func(data []byte, ops []scanOp) error {
+		model := bytes.NewReader(data)
+		s := newScanner(slices.Clone(data))
+		lastRead := false
+
+		for _, op := range ops {
+			var err error
+
+			if op {
+				err = checkUnreadByte(s, model, lastRead)
+				lastRead = false
+			} else {
+				lastRead, err = checkReadByte(s, model)
+			}
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func checkReadByte(s io.ByteScanner, model *bytes.Reader) (bool, error) {
+	expected, merr := model.ReadByte()
+	c, err := s.ReadByte()
+
+	if merr != nil {
+		if !errors.Is(err, io.EOF) {
+			return false, fmt.Errorf("expecting io.EOF, got byte %q, error %v", c, err)
+		}
+
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("expecting byte %q, got error: %w", expected, err)
+	} else if c != expected {
+		return false, fmt.Errorf("expecting byte %q, got %q", expected, c)
+	}
+
+	return true, nil
+}
+
+// checkUnreadByte checks UnreadByte, which must succeed directly after a
+// successful ReadByte, and may otherwise either fail or move back a byte.
+func checkUnreadByte(s io.ByteScanner, model *bytes.Reader, lastRead bool) error {
+	err := s.UnreadByte()
+
+	if err != nil {
+		if lastRead {
+			return fmt.Errorf("unexpected error: %w", err)
+		}
+
+		return nil
+	} else if int64(model.Len()) == model.Size() {
+		return errors.New("UnreadByte succeeded at the start of the data")
+	}
+
+	return model.UnreadByte()
+}
@@ -0,0 +1,244 @@
+package memiotest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type readOp int
+
+func (r readOp) String() string {
+	return fmt.Sprintf("Read(make([]byte, %d))", int(r))
+}
+
+func genRead(r *rand.Rand, size int) int {
+	return r.IntN(size/4 + 2)
+}
+
+// TestReader tests that the io.Reader returned by newReader satisfies the
+// io.Reader contract and reads the data it was given.
+func TestReader(t testing.TB, newReader func(data []byte) io.Reader) {
+	t.Helper()
+
+	run(t, func(r *rand.Rand, size int) readOp {
+		return readOp(genRead(r, size))
+	}, func(data []byte, ops []readOp) error {
+		model := bytes.NewReader(data)
+		rd := newReader(slices.Clone(data))
+
+		for _, op := range ops {
+			if err := checkRead(rd, model, int(op)); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// checkRead reads size bytes from r and checks the result against the model,
+// advancing the model by the number of bytes read.
+func checkRead(r io.Reader, model *bytes.Reader, size int) error {
+	p := make([]byte, size)
+	n, err := r.Read(p)
+	remaining := model.Len()
+
+	if n < 0 || n > len(p) {
+		return fmt.Errorf("invalid byte count %d", n)
+	} else if err != nil && err != io.EOF {
+		return fmt.Errorf("unexpected error: %w", err)
+	} else if n > remaining {
+		return fmt.Errorf("read %d bytes, with only %d remaining", n, remaining)
+	} else if len(p) > 0 && n == 0 && err == nil {
+		return errors.New("read 0 bytes with nil error")
+	} else if err == io.EOF && remaining > n {
+		return fmt.Errorf("received io.EOF with %d bytes remaining", remaining-n)
+	}
+
+	expected := make([]byte, n)
+
+	model.Read(expected)
+
+	if !bytes.Equal(p[:n], expected) {
+		return fmt.Errorf("read %q, expecting %q", p[:n], expected)
+	}
+
+	return nil
+}
+
+type readAtOp struct {
+	n   int
+	off int64
+}
+
+func (r readAtOp) String() string {
+	return fmt.Sprintf("ReadAt(make([]byte, %d), %d)", r.n, r.off)
+}
+
+// TestReaderAt tests that the io.ReaderAt returned by newReaderAt satisfies
+// the io.ReaderAt contract and reads the data it was given.
+func TestReaderAt(t testing.TB, newReaderAt func(data []byte) io.ReaderAt) {
+	t.Helper()
+
+	run(t, func(r *rand.Rand, size int) readAtOp {
+		return readAtOp{n: genRead(r, size), off: int64(r.IntN(size+8) - 2)}
+	}, func(data []byte, ops []readAtOp) error {
+		ra := newReaderAt(slices.Clone(data))
+
+		for _, op := range ops {
+			if err := checkReadAt(ra, data, op); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func checkReadAt(ra io.ReaderAt, data []byte, op readAtOp) error {
+	p := make([]byte, op.n)
+	n, err := ra.ReadAt(p, op.off)
+
+	if op.off < 0 {
+		if err == nil {
+			return errors.New("expecting error for negative offset")
+		}
+
+		return nil
+	}
+
+	expected := data[min(op.off, int64(len(data))):]
+	expected = expected[:min(len(expected), op.n)]
+
+	if n < 0 || n > len(p) {
+		return fmt.Errorf("invalid byte count %d", n)
+	} else if n < len(p) && err == nil {
+		return fmt.Errorf("read %d bytes, expecting %d, with nil error", n, len(p))
+	} else if err != nil && err != io.EOF {
+		return fmt.Errorf("unexpected error: %w", err)
+	} else if n != len(expected) {
+		return fmt.Errorf("read %d bytes, expecting %d", n, len(expected))
+	} else if err == io.EOF && op.off+int64(n) < int64(len(data)) {
+		return fmt.Errorf("received io.EOF with %d bytes remaining", int64(len(data))-op.off-int64(n))
+	} else if !bytes.Equal(p[:n], expected) {
+		return fmt.Errorf("read %q, expecting %q", p[:n], expected)
+	}
+
+	return nil
+}
+
+var errWriteLimit = errors.New("write limit reached")
+
+type writeToOp struct {
+	read  bool
+	limit int
+}
+
+func (w writeToOp) String() string {
+	if w.read {
+		return readOp(w.limit).String()
+	} else if w.limit < 0 {
+		return "WriteTo(w)"
+	}
+
+	return fmt.Sprintf("WriteTo(w) // w accepts %d bytes", w.limit)
+}
+
+// TestWriterTo tests that the io.WriterTo returned by newWriterTo satisfies
+// the io.WriterTo contract and writes the data it was given, including when
+// the destination writer fails.
+//
+// If the WriterTo implements io.Reader, reads are interleaved with the
+// WriteTo calls.
+func TestWriterTo(t testing.TB, newWriterTo func(data []byte) io.WriterTo) {
+	t.Helper()
+
+	run(t, func(r *rand.Rand, size int) writeToOp {
+		switch r.IntN(3) {
+		case 0:
+			return writeToOp{read: true, limit: genRead(r, size)}
+		case 1:
+			return writeToOp{limit: -1}
+		}
+
+		return writeToOp{limit: genRead(r, size)}
+	}, func(data []byte, ops []writeToOp) error {
+		model := bytes.NewReader(data)
+		wt := newWriterTo(slices.Clone(data))
+
+		for _, op := range ops {
+			var err error
+
+			if !op.read {
+				err = checkWriteTo(wt, model, op.limit)
+			} else if rd, ok := wt.(io.Reader); ok {
+				err = checkRead(rd, model, op.limit)
+			}
+
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// checkWriteTo calls WriteTo with a writer that accepts limit bytes, or any
+// number when limit is negative, and checks the result against the model,
+// advancing the model by the number of bytes written.
+func checkWriteTo(wt io.WriterTo, model *bytes.Reader, limit int) error {
+	w := &limitedWriter{limit: limit}
+	n, err := wt.WriteTo(w)
+	expected := model.Len()
+	short := limit >= 0 && limit < expected
+
+	if short {
+		expected = limit
+	}
+
+	if n != int64(len(w.data)) {
+		return fmt.Errorf("returned count %d, but wrote %d bytes", n, len(w.data))
+	} else if n != int64(expected) {
+		return fmt.Errorf("wrote %d bytes, expecting %d", n, expected)
+	} else if short && err != errWriteLimit {
+		return fmt.Errorf("expecting writer error, got %v", err)
+	} else if !short && err != nil {
+		return fmt.Errorf("unexpected error: %w", err)
+	}
+
+	p := make([]byte, n)
+
+	model.Read(p)
+
+	if !bytes.Equal(w.data, p) {
+		return fmt.Errorf("wrote %q, expecting %q", w.data, p)
+	}
+
+	return nil
+}
+
+// limitedWriter accepts writes up to a total of limit bytes, returning
+// errWriteLimit for any beyond it. A negative limit accepts all writes.
+type limitedWriter struct {
+	data  []byte
+	limit int
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if l.limit >= 0 && len(p) > l.limit-len(l.data) {
+		n := l.limit - len(l.data)
+		l.data = append(l.data, p[:n]...)
+
+		return n, errWriteLimit
+	}
+
+	l.data = append(l.data, p...)
+
+	return len(p), nil
+}
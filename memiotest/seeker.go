@@ -0,0 +1,82 @@
+package memiotest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+var whences = [...]string{"io.SeekStart", "io.SeekCurrent", "io.SeekEnd"}
+
+type seekOp struct {
+	seek   bool
+	offset int64
+	whence int
+	n      int
+}
+
+func (s seekOp) String() string {
+	if !s.seek {
+		return readOp(s.n).String()
+	}
+
+	whence := fmt.Sprint(s.whence)
+
+	if s.whence >= 0 && s.whence < len(whences) {
+		whence = whences[s.whence]
+	}
+
+	return fmt.Sprintf("Seek(%d, %s)", s.offset, whence)
+}
+
+// TestSeeker tests that the io.ReadSeeker returned by newSeeker satisfies the
+// io.Seeker contract, comparing the positions and data read with those of a
+// bytes.Reader.
+func TestSeeker(t testing.TB, newSeeker func(data []byte) io.ReadSeeker) {
+	t.Helper()
+
+	run(t, func(r *rand.Rand, size int) seekOp {
+		if r.IntN(2) == 0 {
+			return seekOp{n: genRead(r, size)}
+		}
+
+		return seekOp{
+			seek:   true,
+			offset: int64(r.IntN(2*size+20) - size - 10),
+			whence: r.IntN(len(whences)*8+1) / 8,
+		}
+	}, func(data []byte, ops []seekOp) error {
+		model := bytes.NewReader(data)
+		s := newSeeker(slices.Clone(data))
+
+		for _, op := range ops {
+			if err := checkSeek(s, model, op); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func checkSeek(s io.ReadSeeker, model *bytes.Reader, op seekOp) error {
+	if !op.seek {
+		return checkRead(s, model, op.n)
+	}
+
+	expected, merr := model.Seek(op.offset, op.whence)
+	pos, err := s.Seek(op.offset, op.whence)
+
+	if merr != nil && err == nil {
+		return fmt.Errorf("expecting error %q, got position %d", merr, pos)
+	} else if merr == nil && err != nil {
+		return fmt.Errorf("expecting position %d, got error: %w", expected, err)
+	} else if err == nil && pos != expected {
+		return fmt.Errorf("expecting position %d, got %d", expected, pos)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package memiotest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"testing"
+)
+
+const (
+	opWrite = iota
+	opWriteString
+	opWriteByte
+)
+
+type writeOp struct {
+	kind int
+	data []byte
+}
+
+func (w writeOp) String() string {
+	switch w.kind {
+	case opWriteString:
+		return fmt.Sprintf("WriteString(%q)", w.data)
+	case opWriteByte:
+		return fmt.Sprintf("WriteByte(%q)", w.data[0])
+	}
+
+	return fmt.Sprintf("Write([]byte(%q))", w.data)
+}
+
+// TestWriter tests that the io.Writer returned by newWriter satisfies the
+// io.Writer contract, using the returned function to retrieve the data
+// written so far.
+//
+// If the writer implements io.StringWriter or io.ByteWriter, those methods are
+// also tested.
+func TestWriter(t testing.TB, newWriter func() (io.Writer, func() []byte)) {
+	t.Helper()
+
+	run(t, func(r *rand.Rand, size int) writeOp {
+		kind := r.IntN(3)
+
+		if kind == opWriteByte {
+			return writeOp{kind: kind, data: randomText(r, 1)}
+		}
+
+		return writeOp{kind: kind, data: randomText(r, r.IntN(size/4+2))}
+	}, func(_ []byte, ops []writeOp) error {
+		var model bytes.Buffer
+
+		w, contents := newWriter()
+
+		for _, op := range ops {
+			if err := checkWrite(w, &model, op); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			} else if got := contents(); !bytes.Equal(got, model.Bytes()) {
+				return fmt.Errorf("%s: contents are %q, expecting %q", op, got, model.Bytes())
+			}
+		}
+
+		return nil
+	})
+}
+
+func checkWrite(w io.Writer, model *bytes.Buffer, op writeOp) error {
+	var (
+		n   int
+		err error
+	)
+
+	switch op.kind {
+	case opWriteString:
+		if sw, ok := w.(io.StringWriter); ok {
+			n, err = sw.WriteString(string(op.data))
+		} else {
+			n, err = w.Write(op.data)
+		}
+	case opWriteByte:
+		if bw, ok := w.(io.ByteWriter); ok {
+			if err = bw.WriteByte(op.data[0]); err == nil {
+				n = 1
+			}
+		} else {
+			n, err = w.Write(op.data)
+		}
+	default:
+		n, err = w.Write(op.data)
+	}
+
+	if n < 0 || n > len(op.data) {
+		return fmt.Errorf("invalid byte count %d", n)
+	} else if n < len(op.data) && err == nil {
+		return fmt.Errorf("wrote %d bytes, expecting %d, with nil error", n, len(op.data))
+	}
+
+	model.Write(op.data[:n])
+
+	return nil
+}
+
+type writeAtOp struct {
+	off  int64
+	data []byte
+}
+
+func (w writeAtOp) String() string {
+	return fmt.Sprintf("WriteAt([]byte(%q), %d)", w.data, w.off)
+}
+
+// TestWriterAt tests that the io.WriterAt returned by newWriterAt satisfies
+// the io.WriterAt contract, comparing the data written, as retrieved by the
+// returned function, with that of a temporary file.
+func TestWriterAt(t testing.TB, newWriterAt func() (io.WriterAt, func() []byte)) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	run(t, func(r *rand.Rand, size int) writeAtOp {
+		return writeAtOp{
+			off:  int64(r.IntN(size/2+4) - 2),
+			data: randomText(r, r.IntN(size/8+2)),
+		}
+	}, func(_ []byte, ops []writeAtOp) error {
+		model, err := os.CreateTemp(dir, "")
+		if err != nil {
+			return err
+		}
+
+		defer os.Remove(model.Name())
+		defer model.Close()
+
+		w, contents := newWriterAt()
+
+		for _, op := range ops {
+			if err := checkWriteAt(w, model, op); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			expected, err := os.ReadFile(model.Name())
+			if err != nil {
+				return err
+			} else if got := contents(); !bytes.Equal(got, expected) {
+				return fmt.Errorf("%s: contents are %q, expecting %q", op, got, expected)
+			}
+		}
+
+		return nil
+	})
+}
+
+func checkWriteAt(w io.WriterAt, model *os.File, op writeAtOp) error {
+	n, err := w.WriteAt(op.data, op.off)
+
+	if op.off < 0 {
+		if err == nil {
+			return errors.New("expecting error for negative offset")
+		} else if n != 0 {
+			return fmt.Errorf("wrote %d bytes at negative offset", n)
+		}
+
+		return nil
+	} else if n < 0 || n > len(op.data) {
+		return fmt.Errorf("invalid byte count %d", n)
+	} else if n < len(op.data) && err == nil {
+		return fmt.Errorf("wrote %d bytes, expecting %d, with nil error", n, len(op.data))
+	}
+
+	if n > 0 {
+		if _, err := model.WriteAt(op.data[:n], op.off); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
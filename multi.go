@@ -0,0 +1,219 @@
+package memio
+
+import (
+	"io"
+	"net"
+	"sort"
+)
+
+// MultiMem presents a number of byte slices as a single, read-only, stream.
+type MultiMem struct {
+	segments [][]byte
+	starts   []int64
+	size     int64
+	pos      int64
+	closed   bool
+}
+
+// OpenMulti uses the given byte slices, in order, for reading. Implements
+// io.Reader, io.Seeker, io.ReaderAt, io.ByteScanner, io.WriterTo and io.Closer.
+//
+// The slices are not copied, and so should not be modified while in use.
+func OpenMulti(slices ...[]byte) *MultiMem {
+	m := new(MultiMem)
+
+	m.Append(slices...)
+
+	return m
+}
+
+// Append adds the given byte slices to the end of the stream, without copying.
+func (m *MultiMem) Append(slices ...[]byte) {
+	for _, s := range slices {
+		if len(s) > 0 {
+			m.segments = append(m.segments, s)
+			m.starts = append(m.starts, m.size)
+			m.size += int64(len(s))
+		}
+	}
+}
+
+// Size returns the total length of the stream.
+func (m *MultiMem) Size() int64 {
+	return m.size
+}
+
+// Len returns the number of unread bytes.
+func (m *MultiMem) Len() int {
+	if m.pos >= m.size {
+		return 0
+	}
+
+	return int(m.size - m.pos)
+}
+
+// Read is an implementation of the io.Reader interface.
+func (m *MultiMem) Read(p []byte) (int, error) {
+	if m.closed {
+		return 0, opError("read", m.pos, ErrClosed)
+	} else if m.pos >= m.size {
+		return 0, io.EOF
+	}
+
+	n := m.copyAt(p, m.pos)
+	m.pos += int64(n)
+
+	return n, nil
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (m *MultiMem) ReadAt(p []byte, off int64) (int, error) {
+	if m.closed {
+		return 0, opError("readat", off, ErrClosed)
+	} else if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= m.size {
+		return 0, io.EOF
+	}
+
+	n := m.copyAt(p, off)
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// ReadByte is an implementation of the io.ByteReader interface.
+func (m *MultiMem) ReadByte() (byte, error) {
+	if m.closed {
+		return 0, opError("readbyte", m.pos, ErrClosed)
+	} else if m.pos >= m.size {
+		return 0, io.EOF
+	}
+
+	s := m.segment(m.pos)
+	c := m.segments[s][m.pos-m.starts[s]]
+	m.pos++
+
+	return c, nil
+}
+
+// UnreadByte is an implementation of the io.ByteScanner interface.
+func (m *MultiMem) UnreadByte() error {
+	if m.closed {
+		return opError("unreadbyte", m.pos, ErrClosed)
+	} else if m.pos <= 0 {
+		return ErrInvalidUnreadByte
+	}
+
+	m.pos--
+
+	return nil
+}
+
+// Peek reads the next n bytes without advancing the position.
+//
+// When the bytes are contained within a single slice, the returned slice
+// refers to that slice; otherwise the bytes are copied into a new slice.
+func (m *MultiMem) Peek(n int) ([]byte, error) {
+	if m.closed {
+		return nil, opError("peek", m.pos, ErrClosed)
+	} else if n < 0 {
+		return nil, opError("peek", m.pos, ErrInvalidCount)
+	} else if m.pos >= m.size {
+		return nil, io.EOF
+	}
+
+	var err error
+
+	if left := m.size - m.pos; int64(n) > left {
+		n = int(left)
+		err = io.EOF
+	}
+
+	s := m.segment(m.pos)
+	start := int(m.pos - m.starts[s])
+
+	if start+n <= len(m.segments[s]) {
+		return m.segments[s][start : start+n], err
+	}
+
+	buf := make([]byte, n)
+
+	m.copyAt(buf, m.pos)
+
+	return buf, err
+}
+
+// Seek is an implementation of the io.Seeker interface.
+func (m *MultiMem) Seek(offset int64, whence int) (int64, error) {
+	if m.closed {
+		return 0, opError("seek", offset, ErrClosed)
+	}
+
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += m.pos
+	case seekEnd:
+		offset += m.size
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	m.pos = offset
+
+	return offset, nil
+}
+
+// WriteTo is an implementation of the io.WriterTo interface.
+//
+// The unread slices are written using net.Buffers, allowing writers such as
+// network connections to use vectored IO.
+func (m *MultiMem) WriteTo(w io.Writer) (int64, error) {
+	if m.closed {
+		return 0, opError("writeto", m.pos, ErrClosed)
+	} else if m.pos >= m.size {
+		return 0, nil
+	}
+
+	s := m.segment(m.pos)
+	bufs := make(net.Buffers, 0, len(m.segments)-s)
+	bufs = append(bufs, m.segments[s][m.pos-m.starts[s]:])
+	bufs = append(bufs, m.segments[s+1:]...)
+
+	n, err := bufs.WriteTo(w)
+	m.pos += n
+
+	return n, err
+}
+
+// Close is an implementation of the io.Closer interface.
+func (m *MultiMem) Close() error {
+	m.segments = nil
+	m.starts = nil
+	m.closed = true
+
+	return nil
+}
+
+func (m *MultiMem) segment(off int64) int {
+	return sort.Search(len(m.starts), func(n int) bool {
+		return m.starts[n] > off
+	}) - 1
+}
+
+func (m *MultiMem) copyAt(p []byte, off int64) int {
+	var n int
+
+	for s := m.segment(off); n < len(p) && s < len(m.segments); s++ {
+		n += copy(p[n:], m.segments[s][off+int64(n)-m.starts[s]:])
+	}
+
+	return n
+}
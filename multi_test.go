@@ -0,0 +1,69 @@
+package memio
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+var (
+	_ io.ReadSeeker  = new(MultiMem)
+	_ io.ReaderAt    = new(MultiMem)
+	_ io.ByteScanner = new(MultiMem)
+	_ io.WriterTo    = new(MultiMem)
+	_ io.Closer      = new(MultiMem)
+)
+
+func TestMultiMem(t *testing.T) {
+	m := OpenMulti([]byte("Hello"), nil, []byte(", "), []byte("World"))
+
+	m.Append([]byte("!"))
+
+	buf := make([]byte, 4)
+
+	if n, err := m.Read(buf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != "Hell" {
+		t.Errorf("expecting %q, got %q", "Hell", buf[:n])
+	} else if p, err := m.Peek(4); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(p) != "o, W" {
+		t.Errorf("expecting %q, got %q", "o, W", p)
+	} else if c, err := m.ReadByte(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if c != 'o' {
+		t.Errorf("expecting byte %q, got %q", 'o', c)
+	} else if err = m.UnreadByte(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err = m.ReadAt(buf, 6); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != " Wor" {
+		t.Errorf("expecting %q, got %q", " Wor", buf[:n])
+	} else if n, err = m.ReadAt(buf, 11); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if string(buf[:n]) != "d!" {
+		t.Errorf("expecting %q, got %q", "d!", buf[:n])
+	} else if pos, err := m.Seek(-6, seekEnd); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if pos != 7 {
+		t.Errorf("expecting position 7, got %d", pos)
+	} else if p, err = m.Peek(10); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if string(p) != "World!" {
+		t.Errorf("expecting %q, got %q", "World!", p)
+	}
+
+	var sb bytes.Buffer
+
+	m.Seek(4, seekSet)
+
+	if n, err := m.WriteTo(&sb); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n != 9 {
+		t.Errorf("expecting to write 9 bytes, wrote %d", n)
+	} else if sb.String() != "o, World!" {
+		t.Errorf("expecting %q, got %q", "o, World!", sb.String())
+	} else if _, err = m.Read(buf); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	}
+}
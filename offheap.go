@@ -0,0 +1,170 @@
+package memio
+
+import (
+	"errors"
+	"log"
+	"os"
+	"runtime"
+)
+
+// Advice is a set of hints about how an OffHeapMem will be used.
+type Advice uint8
+
+// Advice values.
+const (
+	// AdviseHugePages requests that the memory be backed by huge pages.
+	AdviseHugePages Advice = 1 << iota
+
+	// AdviseDontNeed releases the memory of whole pages removed by Truncate
+	// back to the operating system.
+	AdviseDontNeed
+)
+
+// OffHeapMem is a ReadWriteMem whose memory is allocated with an anonymous
+// mmap, outside of the Go heap, so that it does not count towards the heap
+// size used for garbage collection pacing. The memory is grown with mremap.
+//
+// Growing the memory may move it, unmapping the old range, so any write that
+// grows an OffHeapMem beyond the size of its mapping invalidates all slices
+// previously obtained from it, such as from Peek; using such a slice afterwards
+// can crash the program. Giving NewOffHeapMem a size large enough for the data
+// avoids moving the memory.
+//
+// The memory must be released with Free, or Close; an OffHeapMem that is
+// garbage collected without having been freed will log a warning, and its
+// memory will remain allocated.
+//
+// OffHeapMem is only supported on 64-bit Linux.
+type OffHeapMem struct {
+	ReadWriteMem
+	mapping *mapping
+}
+
+type mapping struct {
+	mem    []byte
+	advice Advice
+}
+
+// NewOffHeapMem creates an OffHeapMem with space for at least size bytes,
+// using the given advice.
+func NewOffHeapMem(size int, advice Advice) (*OffHeapMem, error) {
+	mem, err := mmap(roundPage(size))
+	if err != nil {
+		return nil, err
+	}
+
+	m := &mapping{mem: mem, advice: advice}
+
+	m.advise()
+	runtime.SetFinalizer(m, (*mapping).leaked)
+
+	data := new([]byte)
+	*data = mem[:0]
+
+	return &OffHeapMem{
+		ReadWriteMem: ReadWriteMem{WriteMem{data: data, alloc: m}},
+		mapping:      m,
+	}, nil
+}
+
+// Truncate changes the length of the byte slice to the given amount.
+//
+// With AdviseDontNeed, whole pages that are removed are released back to the
+// operating system.
+func (o *OffHeapMem) Truncate(s int64) error {
+	if o.data == nil {
+		return opError("truncate", s, ErrClosed)
+	}
+
+	l := len(*o.data)
+
+	if o.mapping.advice&AdviseDontNeed == 0 || s < 0 || s >= int64(l) {
+		return o.WriteMem.Truncate(s)
+	}
+
+	start := roundPage(int(s))
+
+	if end := roundPage(l); start < end {
+		if err := madvise(o.mapping.mem[start:end], madvDontNeed); err != nil {
+			return err
+		}
+	}
+
+	clear((*o.data)[s:min(start, l)])
+
+	*o.data = (*o.data)[:s]
+
+	o.index.update(int(s), l-int(s), 0)
+
+	return nil
+}
+
+// Free releases the memory of the OffHeapMem. Any slices previously obtained,
+// such as from Peek, must no longer be used.
+func (o *OffHeapMem) Free() error {
+	if o.data == nil {
+		return opError("free", 0, ErrClosed)
+	}
+
+	o.data = nil
+
+	runtime.SetFinalizer(o.mapping, nil)
+
+	return o.mapping.free()
+}
+
+// Close is an implementation of the io.Closer interface, and calls Free.
+func (o *OffHeapMem) Close() error {
+	if o.data == nil {
+		return nil
+	}
+
+	return o.Free()
+}
+
+func (m *mapping) realloc(data []byte, size int) ([]byte, error) {
+	mem, err := mremap(m.mem, roundPage(size))
+	if err != nil {
+		return nil, err
+	}
+
+	m.mem = mem
+
+	m.advise()
+
+	return mem[:len(data)], nil
+}
+
+func (m *mapping) advise() {
+	if m.advice&AdviseHugePages != 0 {
+		madvise(m.mem, madvHugePage)
+	}
+}
+
+func (m *mapping) free() error {
+	mem := m.mem
+	m.mem = nil
+
+	return munmap(mem)
+}
+
+// leaked reports a mapping that was garbage collected without being freed. The
+// memory is not released, as slices of it may still be in use.
+func (m *mapping) leaked() {
+	log.Printf("memio: OffHeapMem of %d bytes garbage collected without being freed", len(m.mem))
+}
+
+func roundPage(size int) int {
+	page := os.Getpagesize()
+
+	if size < page {
+		return page
+	}
+
+	return (size + page - 1) &^ (page - 1)
+}
+
+// Errors.
+var (
+	ErrOffHeapUnsupported = errors.New("off-heap memory not supported on this platform")
+)
@@ -0,0 +1,50 @@
+//go:build linux && (amd64 || arm64 || loong64 || mips64 || mips64le || ppc64 || ppc64le || riscv64)
+
+package memio
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+const (
+	madvDontNeed  = syscall.MADV_DONTNEED
+	madvHugePage  = syscall.MADV_HUGEPAGE
+	mremapMayMove = 1
+)
+
+func mmap(size int) ([]byte, error) {
+	addr, _, errno := syscall.Syscall6(syscall.SYS_MMAP, 0, uintptr(size), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_PRIVATE|syscall.MAP_ANONYMOUS, ^uintptr(0), 0)
+	if errno != 0 {
+		return nil, errno
+	}
+
+	return mapped(addr, size), nil
+}
+
+func mremap(mem []byte, size int) ([]byte, error) {
+	addr, _, errno := syscall.Syscall6(syscall.SYS_MREMAP, uintptr(unsafe.Pointer(unsafe.SliceData(mem))), uintptr(len(mem)), uintptr(size), mremapMayMove, 0, 0)
+	if errno != 0 {
+		return nil, errno
+	}
+
+	return mapped(addr, size), nil
+}
+
+func munmap(mem []byte) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_MUNMAP, uintptr(unsafe.Pointer(unsafe.SliceData(mem))), uintptr(len(mem)), 0); errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+func madvise(mem []byte, advice int) error {
+	return syscall.Madvise(mem, advice)
+}
+
+// mapped converts the address of a mapping, which is outside of the Go heap,
+// into a byte slice.
+func mapped(addr uintptr, size int) []byte {
+	return unsafe.Slice((*byte)(*(*unsafe.Pointer)(unsafe.Pointer(&addr))), size)
+}
@@ -0,0 +1,24 @@
+//go:build !linux || !(amd64 || arm64 || loong64 || mips64 || mips64le || ppc64 || ppc64le || riscv64)
+
+package memio
+
+const (
+	madvDontNeed = 0
+	madvHugePage = 0
+)
+
+func mmap(int) ([]byte, error) {
+	return nil, ErrOffHeapUnsupported
+}
+
+func mremap([]byte, int) ([]byte, error) {
+	return nil, ErrOffHeapUnsupported
+}
+
+func munmap([]byte) error {
+	return ErrOffHeapUnsupported
+}
+
+func madvise([]byte, int) error {
+	return ErrOffHeapUnsupported
+}
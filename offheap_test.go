@@ -0,0 +1,73 @@
+package memio
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestOffHeapMem(t *testing.T) {
+	o, err := NewOffHeapMem(10, AdviseDontNeed|AdviseHugePages)
+	if err == ErrOffHeapUnsupported {
+		t.Skip(err)
+	} else if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data := bytes.Repeat([]byte("0123456789"), 100000)
+
+	if n, err := o.Write(data); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if n != len(data) {
+		t.Fatalf("expecting to write %d bytes, wrote %d", len(data), n)
+	}
+
+	buf := make([]byte, len(data))
+
+	if n, err := o.ReadAt(buf, 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !bytes.Equal(buf[:n], data) {
+		t.Fatalf("read data does not match written data")
+	} else if err = o.Truncate(5); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if err = o.Truncate(20000); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if n, err = o.ReadAt(buf[:20000], 0); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if string(buf[:5]) != "01234" {
+		t.Fatalf("expecting %q, got %q", "01234", buf[:5])
+	} else if !bytes.Equal(buf[5:n], make([]byte, 19995)) {
+		t.Fatalf("expecting truncated data to be zeroed")
+	} else if err = o.Free(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if _, err = o.Write(data); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expecting ErrClosed, got %v", err)
+	} else if err = o.Free(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expecting ErrClosed, got %v", err)
+	}
+}
+
+type failingAllocator struct{}
+
+func (failingAllocator) realloc([]byte, int) ([]byte, error) {
+	return nil, ErrOffHeapUnsupported
+}
+
+func TestReallocError(t *testing.T) {
+	data := make([]byte, 0, 4)
+	w := &WriteMem{data: &data, alloc: failingAllocator{}}
+
+	if n, err := w.Write([]byte("abc")); n != 3 || err != nil {
+		t.Errorf("expecting to write 3 bytes with nil error, wrote %d with %v", n, err)
+	} else if n, err = w.Write([]byte("de")); n != 0 || !errors.Is(err, ErrOffHeapUnsupported) {
+		t.Errorf("expecting to write 0 bytes with ErrOffHeapUnsupported, wrote %d with %v", n, err)
+	} else if _, err = w.WriteAt([]byte("de"), 3); !errors.Is(err, ErrOffHeapUnsupported) {
+		t.Errorf("expecting ErrOffHeapUnsupported, got %v", err)
+	} else if _, err = w.ReadFrom(bytes.NewReader([]byte("de"))); !errors.Is(err, ErrOffHeapUnsupported) {
+		t.Errorf("expecting ErrOffHeapUnsupported, got %v", err)
+	} else if err = w.Truncate(10); !errors.Is(err, ErrOffHeapUnsupported) {
+		t.Errorf("expecting ErrOffHeapUnsupported, got %v", err)
+	} else if string(data) != "abc" {
+		t.Errorf("expecting %q, got %q", "abc", data)
+	}
+}
@@ -0,0 +1,57 @@
+package memio
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+const maxInt = int64(math.MaxInt)
+
+// maxAlloc is the largest allocation that will be attempted; larger sizes,
+// which make would panic on, return ErrTooLarge.
+const maxAlloc = min(maxInt, 1<<47)
+
+// OpError is returned by the memio types when an operation is given an invalid
+// argument, such as a negative offset or invalid whence, or is attempted after
+// Close.
+//
+// The underlying error, such as ErrInvalidOffset, ErrInvalidWhence or
+// ErrClosed, can be checked for using errors.Is.
+type OpError struct {
+	Op     string
+	Offset int64
+	Err    error
+}
+
+func opError(op string, off int64, err error) error {
+	return &OpError{Op: op, Offset: off, Err: err}
+}
+
+// Error implements the error interface.
+func (o *OpError) Error() string {
+	return o.Op + " at offset " + strconv.FormatInt(o.Offset, 10) + ": " + o.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (o *OpError) Unwrap() error {
+	return o.Err
+}
+
+// validRange returns true if the n bytes starting at off can be addressed by an
+// int.
+func validRange(off, n int64) bool {
+	return off >= 0 && n >= 0 && off <= maxInt-n
+}
+
+// fits returns true if a buffer of length l can grow by n bytes without
+// exceeding maxAlloc.
+func fits(l, n int) bool {
+	return n >= 0 && int64(n) <= maxAlloc-int64(l)
+}
+
+// Errors.
+var (
+	ErrInvalidCount = errors.New("invalid count")
+	ErrTooLarge     = errors.New("size too large")
+)
@@ -0,0 +1,111 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestOpError(t *testing.T) {
+	var (
+		data = []byte("Hello")
+		opE  *OpError
+		buf  = Buffer("Hello")
+		lb   = make(LimitedBuffer, 0, 5)
+		rw   = OpenMem(&data)
+		str  = String("Hello")
+		rm   = Open([]byte("Hello"))
+		b    [10]byte
+	)
+
+	if _, err := buf.ReadAt(b[:], -1); !errors.As(err, &opE) {
+		t.Errorf("expecting *OpError, got %v", err)
+	} else if opE.Op != "readat" || opE.Offset != -1 || opE.Err != ErrInvalidOffset {
+		t.Errorf("expecting readat at -1 with ErrInvalidOffset, got %#v", opE)
+	} else if n, err := buf.ReadAt(b[:], 10); n != 0 || err != io.EOF {
+		t.Errorf("expecting 0, io.EOF, got %d, %v", n, err)
+	} else if n, err = buf.ReadAt(b[:], 2); n != 3 || err != io.EOF {
+		t.Errorf("expecting 3, io.EOF, got %d, %v", n, err)
+	} else if _, err = buf.WriteAt(b[:], math.MaxInt64); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if _, err = buf.Peek(-1); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("expecting ErrInvalidCount, got %v", err)
+	} else if n, err = lb.WriteString("Hello, World"); n != 5 || err != io.ErrShortWrite {
+		t.Errorf("expecting 5, io.ErrShortWrite, got %d, %v", n, err)
+	} else if err = lb.WriteByte('!'); err != io.ErrShortWrite {
+		t.Errorf("expecting io.ErrShortWrite, got %v", err)
+	} else if n, err = lb.ReadAt(b[:], 3); n != 2 || err != io.EOF {
+		t.Errorf("expecting 2, io.EOF, got %d, %v", n, err)
+	} else if n, err = lb.WriteAt([]byte("J"), 0); n != 1 || err != nil {
+		t.Errorf("expecting 1, nil, got %d, %v", n, err)
+	} else if n, err = lb.WriteAt([]byte("LLO"), 3); n != 2 || err != io.ErrShortWrite {
+		t.Errorf("expecting 2, io.ErrShortWrite, got %d, %v", n, err)
+	} else if string(lb) != "JelLL" {
+		t.Errorf("expecting %q, got %q", "JelLL", lb)
+	} else if _, err = lb.WriteAt(b[:], -1); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if n, err = rw.ReadAt(b[:], 3); n != 2 || err != io.EOF {
+		t.Errorf("expecting 2, io.EOF, got %d, %v", n, err)
+	} else if _, err = rw.ReadAt(b[:], -3); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if _, err = rw.Peek(-1); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("expecting ErrInvalidCount, got %v", err)
+	} else if _, err = rw.Seek(2, seekSet); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = rw.Seek(0, 3); !errors.Is(err, ErrInvalidWhence) {
+		t.Errorf("expecting ErrInvalidWhence, got %v", err)
+	} else if _, err = rw.Seek(-3, seekCurr); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if pos, _ := rw.Seek(0, seekCurr); pos != 2 {
+		t.Errorf("expecting position to be unchanged at 2, got %d", pos)
+	} else if _, err = rw.WriteAt(b[:], math.MaxInt64-5); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if err = rw.Truncate(-1); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if _, err = str.Peek(-1); !errors.Is(err, ErrInvalidCount) {
+		t.Errorf("expecting ErrInvalidCount, got %v", err)
+	} else if _, err = rm.Seek(-1, seekSet); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	} else if _, err = rm.Seek(0, 5); !errors.Is(err, ErrInvalidWhence) {
+		t.Errorf("expecting ErrInvalidWhence, got %v", err)
+	} else if p, err := rm.Peek(10); string(p) != "Hello" || err != io.EOF {
+		t.Errorf("expecting %q, io.EOF, got %q, %v", "Hello", p, err)
+	} else if err = rw.Close(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = rw.ReadAt(b[:], 1); !errors.As(err, &opE) {
+		t.Errorf("expecting *OpError, got %v", err)
+	} else if opE.Op != "readat" || opE.Offset != 1 || opE.Err != ErrClosed {
+		t.Errorf("expecting readat at 1 with ErrClosed, got %#v", opE)
+	} else if s := opE.Error(); s != "readat at offset 1: operation not permitted when closed" {
+		t.Errorf("unexpected error string: %s", s)
+	} else if err = buf.Close(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = buf.Peek(1); !errors.As(err, &opE) || opE.Op != "peek" || opE.Err != ErrClosed {
+		t.Errorf("expecting peek *OpError with ErrClosed, got %v", err)
+	} else if err = lb.Close(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = lb.Peek(1); !errors.As(err, &opE) || opE.Op != "peek" || opE.Err != ErrClosed {
+		t.Errorf("expecting peek *OpError with ErrClosed, got %v", err)
+	}
+}
+
+func TestWriteToEmpty(t *testing.T) {
+	var (
+		data []byte
+		w    io.Writer = io.Discard
+	)
+
+	for n, wt := range [...]io.WriterTo{
+		new(Buffer),
+		new(LimitedBuffer),
+		new(String),
+		OpenMem(&data),
+		OpenFIFO(&data),
+		NewAlignedBuffer(0, 64),
+	} {
+		if m, err := wt.WriteTo(w); m != 0 || err != nil {
+			t.Errorf("test %d: expecting to write 0 bytes with nil error, wrote %d with %v", n+1, m, err)
+		}
+	}
+}
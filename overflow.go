@@ -0,0 +1,280 @@
+package memio
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// Overflow is a policy that determines how an OverflowBuffer handles data
+// that does not fit within its capacity.
+type Overflow struct {
+	truncate bool
+	marker   string
+	fn       func(*OverflowBuffer, int) error
+}
+
+var (
+	// OverflowError writes as much as will fit and returns io.ErrShortWrite.
+	OverflowError = Overflow{}
+
+	// OverflowTruncate silently discards any data that does not fit.
+	OverflowTruncate = Overflow{truncate: true}
+)
+
+// OverflowMarker discards any data that does not fit and ends the buffer with
+// a marker, trimming the kept data, at a UTF-8 boundary, to make room for it.
+//
+// The marker is formatted, as with the Printf method of LimitedBuffer, with
+// the number of bytes discarded as its only argument, e.g.
+// "…[truncated %d bytes]". Once the marker has been written, all subsequent
+// data is discarded and the marker updated.
+func OverflowMarker(format string) Overflow {
+	return Overflow{truncate: true, marker: format}
+}
+
+// OnOverflow calls fn with the buffer and the number of bytes that do not fit.
+//
+// If fn returns nil, having made room in the buffer, e.g. by calling Flush,
+// the write is retried with the remaining data; if it makes no room,
+// io.ErrShortWrite is returned. Any error returned by fn is returned from the
+// write.
+func OnOverflow(fn func(b *OverflowBuffer, n int) error) Overflow {
+	return Overflow{fn: fn}
+}
+
+// OverflowBuffer is a LimitedBuffer that applies an Overflow policy to Write,
+// WriteString, WriteByte, WriteAt and ReadFrom.
+//
+// The Append and Printf methods of the embedded LimitedBuffer do not apply
+// the policy.
+type OverflowBuffer struct {
+	LimitedBuffer
+	data    []byte
+	policy  Overflow
+	dropped int
+	markAt  int
+}
+
+// NewOverflowBuffer creates an OverflowBuffer with the given capacity and
+// overflow policy.
+func NewOverflowBuffer(size int, policy Overflow) *OverflowBuffer {
+	data := make([]byte, 0, max(size, 0))
+
+	return &OverflowBuffer{
+		LimitedBuffer: data,
+		data:          data,
+		policy:        policy,
+		markAt:        -1,
+	}
+}
+
+// Write satisfies the io.Writer interface.
+func (o *OverflowBuffer) Write(p []byte) (int, error) {
+	return overflowWrite(o, p)
+}
+
+// WriteString writes a string to the buffer without casting to a byte slice.
+func (o *OverflowBuffer) WriteString(str string) (int, error) {
+	return overflowWrite(o, str)
+}
+
+// WriteByte satisfies the io.ByteWriter interface.
+func (o *OverflowBuffer) WriteByte(b byte) error {
+	_, err := o.Write([]byte{b})
+
+	return err
+}
+
+// WriteAt satisfies the io.WriterAt interface.
+//
+// Data written beyond the capacity of the buffer, or over a truncation
+// marker, is handled according to the overflow policy.
+func (o *OverflowBuffer) WriteAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("writeat", off, ErrInvalidOffset)
+	}
+
+	for {
+		limit := int64(o.limit())
+		if off+int64(len(p)) <= limit {
+			return o.LimitedBuffer.WriteAt(p, off)
+		}
+
+		var n int
+
+		if off < limit {
+			n, _ = o.LimitedBuffer.WriteAt(p[:limit-off], off)
+		}
+
+		free := o.free()
+
+		if retry, err := o.overflow(len(p) - n); err != nil {
+			return n, err
+		} else if !retry {
+			return len(p), nil
+		} else if o.free() <= free {
+			return n, io.ErrShortWrite
+		}
+	}
+}
+
+// ReadFrom satisfies the io.ReaderFrom interface.
+//
+// Once the buffer is full, further data read from the Reader is handled
+// according to the overflow policy.
+func (o *OverflowBuffer) ReadFrom(r io.Reader) (int64, error) {
+	var (
+		n   int64
+		buf []byte
+	)
+
+	for {
+		var (
+			m   int
+			err error
+		)
+
+		if free := o.free(); free > 0 {
+			m, err = r.Read(o.LimitedBuffer[len(o.LimitedBuffer) : len(o.LimitedBuffer)+free])
+			o.LimitedBuffer = o.LimitedBuffer[:len(o.LimitedBuffer)+m]
+			n += int64(m)
+		} else {
+			if buf == nil {
+				buf = make([]byte, minRead)
+			}
+
+			m, err = r.Read(buf)
+			if m > 0 {
+				w, werr := o.Write(buf[:m])
+				n += int64(w)
+
+				if werr != nil {
+					return n, werr
+				}
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+
+			return n, err
+		}
+	}
+}
+
+// Dropped returns the number of bytes discarded by the OverflowTruncate and
+// OverflowMarker policies.
+func (o *OverflowBuffer) Dropped() int {
+	return o.dropped
+}
+
+// Flush writes the contents of the buffer to w and, if successful, resets the
+// buffer.
+func (o *OverflowBuffer) Flush(w io.Writer) error {
+	if len(o.LimitedBuffer) > 0 {
+		if _, err := o.LimitedBuffer.WriteTo(w); err != nil {
+			return err
+		}
+	}
+
+	o.Reset()
+
+	return nil
+}
+
+// Reset empties the buffer, restoring its full capacity and clearing any
+// truncation marker and dropped count.
+func (o *OverflowBuffer) Reset() {
+	o.LimitedBuffer = o.data[:0]
+	o.dropped = 0
+	o.markAt = -1
+}
+
+func overflowWrite[T string | []byte](o *OverflowBuffer, p T) (int, error) {
+	var n int
+
+	for {
+		m := min(len(p), o.free())
+		o.LimitedBuffer = append(o.LimitedBuffer, p[:m]...)
+		n += m
+		p = p[m:]
+
+		if len(p) == 0 {
+			return n, nil
+		}
+
+		free := o.free()
+
+		if retry, err := o.overflow(len(p)); err != nil {
+			return n, err
+		} else if !retry {
+			return n + len(p), nil
+		} else if o.free() <= free {
+			return n, io.ErrShortWrite
+		}
+	}
+}
+
+// limit returns the length to which the buffer can be written, which stops at
+// the truncation marker once it has been written.
+func (o *OverflowBuffer) limit() int {
+	if o.markAt >= 0 {
+		return o.markAt
+	}
+
+	return cap(o.LimitedBuffer)
+}
+
+// free returns the number of bytes that can be appended to the buffer.
+func (o *OverflowBuffer) free() int {
+	return max(o.limit()-len(o.LimitedBuffer), 0)
+}
+
+// overflow applies the overflow policy to n bytes that did not fit, returning
+// true if the write should be retried.
+func (o *OverflowBuffer) overflow(n int) (bool, error) {
+	if o.policy.fn != nil {
+		return true, o.policy.fn(o, n)
+	} else if !o.policy.truncate {
+		return false, io.ErrShortWrite
+	}
+
+	o.dropped += n
+
+	if o.policy.marker != "" {
+		o.mark()
+	}
+
+	return false, nil
+}
+
+// mark (re)writes the truncation marker, trimming the kept data to make room
+// for it.
+func (o *OverflowBuffer) mark() {
+	if o.markAt >= 0 {
+		o.LimitedBuffer = o.LimitedBuffer[:o.markAt]
+	}
+
+	var buf [64]byte
+
+	for {
+		marker := appendf(buf[:0], o.policy.marker, []any{o.dropped})
+		keep := min(len(o.LimitedBuffer), max(cap(o.LimitedBuffer)-len(marker), 0))
+
+		for keep > 0 && keep < len(o.LimitedBuffer) && !utf8.RuneStart(o.LimitedBuffer[keep]) {
+			keep--
+		}
+
+		if keep == len(o.LimitedBuffer) {
+			o.markAt = keep
+			o.LimitedBuffer = append(o.LimitedBuffer, marker[:min(len(marker), cap(o.LimitedBuffer)-keep)]...)
+
+			return
+		}
+
+		o.dropped += len(o.LimitedBuffer) - keep
+		o.LimitedBuffer = o.LimitedBuffer[:keep]
+	}
+}
@@ -0,0 +1,148 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ io.Writer       = &OverflowBuffer{}
+	_ io.StringWriter = &OverflowBuffer{}
+	_ io.ByteWriter   = &OverflowBuffer{}
+	_ io.WriterAt     = &OverflowBuffer{}
+	_ io.ReaderFrom   = &OverflowBuffer{}
+)
+
+func TestOverflowError(t *testing.T) {
+	o := NewOverflowBuffer(5, OverflowError)
+
+	if n, err := o.WriteString("1234"); n != 4 || err != nil {
+		t.Errorf("expecting to write 4 bytes with nil error, wrote %d with %v", n, err)
+	} else if n, err = o.Write([]byte("56")); n != 1 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 1 byte with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if err = o.WriteByte('7'); err != io.ErrShortWrite {
+		t.Errorf("expecting io.ErrShortWrite, got %v", err)
+	} else if n, err = o.WriteAt([]byte("ab"), 4); n != 1 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 1 byte with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if m, err := o.ReadFrom(strings.NewReader("8")); m != 0 || err != io.ErrShortWrite {
+		t.Errorf("expecting to read 0 bytes with io.ErrShortWrite, read %d with %v", m, err)
+	} else if string(o.LimitedBuffer) != "1234a" {
+		t.Errorf("expecting %q, got %q", "1234a", o.LimitedBuffer)
+	} else if o.Reset(); len(o.LimitedBuffer) != 0 {
+		t.Errorf("expecting empty buffer, got %q", o.LimitedBuffer)
+	} else if m, err := o.ReadFrom(strings.NewReader("abc")); m != 3 || err != nil {
+		t.Errorf("expecting to read 3 bytes with nil error, read %d with %v", m, err)
+	} else if _, err = o.WriteAt([]byte("a"), -1); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	}
+}
+
+func TestOverflowTruncate(t *testing.T) {
+	o := NewOverflowBuffer(5, OverflowTruncate)
+
+	if n, err := o.WriteString("1234"); n != 4 || err != nil {
+		t.Errorf("expecting to write 4 bytes with nil error, wrote %d with %v", n, err)
+	} else if n, err = o.Write([]byte("56")); n != 2 || err != nil {
+		t.Errorf("expecting to write 2 bytes with nil error, wrote %d with %v", n, err)
+	} else if err = o.WriteByte('7'); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err = o.WriteAt([]byte("ab"), 4); n != 2 || err != nil {
+		t.Errorf("expecting to write 2 bytes with nil error, wrote %d with %v", n, err)
+	} else if m, err := o.ReadFrom(strings.NewReader(strings.Repeat("8", 1000))); m != 1000 || err != nil {
+		t.Errorf("expecting to read 1000 bytes with nil error, read %d with %v", m, err)
+	} else if string(o.LimitedBuffer) != "1234a" {
+		t.Errorf("expecting %q, got %q", "1234a", o.LimitedBuffer)
+	} else if o.Dropped() != 1003 {
+		t.Errorf("expecting 1003 dropped bytes, got %d", o.Dropped())
+	}
+}
+
+func TestOverflowMarker(t *testing.T) {
+	o := NewOverflowBuffer(20, OverflowMarker("…[%d]"))
+
+	if n, err := o.WriteString("Hello, 世界!"); n != 14 || err != nil {
+		t.Errorf("expecting to write 14 bytes with nil error, wrote %d with %v", n, err)
+	} else if n, err = o.WriteString(" abcdefg"); n != 8 || err != nil {
+		t.Errorf("expecting to write 8 bytes with nil error, wrote %d with %v", n, err)
+	} else if expected := "Hello, 世界!…[8]"; string(o.LimitedBuffer) != expected {
+		t.Errorf("expecting %q, got %q", expected, o.LimitedBuffer)
+	} else if err = o.WriteByte('a'); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if expected = "Hello, 世界!…[9]"; string(o.LimitedBuffer) != expected {
+		t.Errorf("expecting %q, got %q", expected, o.LimitedBuffer)
+	} else if m, err := o.ReadFrom(strings.NewReader(strings.Repeat("a", 100))); m != 100 || err != nil {
+		t.Errorf("expecting to read 100 bytes with nil error, read %d with %v", m, err)
+	} else if expected = "Hello, 世…[113]"; string(o.LimitedBuffer) != expected {
+		t.Errorf("expecting %q, got %q", expected, o.LimitedBuffer)
+	} else if n, err = o.WriteAt([]byte("J"), 0); n != 1 || err != nil {
+		t.Errorf("expecting to write 1 byte with nil error, wrote %d with %v", n, err)
+	} else if n, err = o.WriteAt([]byte("abcdefghijk"), 5); n != 11 || err != nil {
+		t.Errorf("expecting to write 11 bytes with nil error, wrote %d with %v", n, err)
+	} else if expected = "Jelloabcde…[119]"; string(o.LimitedBuffer) != expected {
+		t.Errorf("expecting %q, got %q", expected, o.LimitedBuffer)
+	} else if o.Dropped() != 119 {
+		t.Errorf("expecting 119 dropped bytes, got %d", o.Dropped())
+	}
+
+	o = NewOverflowBuffer(3, OverflowMarker("[truncated]"))
+
+	if n, err := o.WriteString("abcd"); n != 4 || err != nil {
+		t.Errorf("expecting to write 4 bytes with nil error, wrote %d with %v", n, err)
+	} else if string(o.LimitedBuffer) != "[tr" {
+		t.Errorf("expecting %q, got %q", "[tr", o.LimitedBuffer)
+	}
+}
+
+func TestOnOverflow(t *testing.T) {
+	var (
+		sb    strings.Builder
+		calls int
+	)
+
+	o := NewOverflowBuffer(4, OnOverflow(func(b *OverflowBuffer, n int) error {
+		calls++
+
+		return b.Flush(&sb)
+	}))
+
+	if n, err := o.WriteString("Hello, World"); n != 12 || err != nil {
+		t.Errorf("expecting to write 12 bytes with nil error, wrote %d with %v", n, err)
+	} else if calls != 2 {
+		t.Errorf("expecting 2 calls, got %d", calls)
+	} else if sb.String() != "Hello, W" {
+		t.Errorf("expecting %q, got %q", "Hello, W", sb.String())
+	} else if err = o.WriteByte('!'); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if m, err := o.ReadFrom(strings.NewReader("abcdef")); m != 6 || err != nil {
+		t.Errorf("expecting to read 6 bytes with nil error, read %d with %v", m, err)
+	} else if sb.String() != "Hello, World!abc" {
+		t.Errorf("expecting %q, got %q", "Hello, World!abc", sb.String())
+	} else if string(o.LimitedBuffer) != "def" {
+		t.Errorf("expecting %q, got %q", "def", o.LimitedBuffer)
+	}
+
+	errStop := errors.New("stop")
+	o = NewOverflowBuffer(2, OnOverflow(func(*OverflowBuffer, int) error { return errStop }))
+
+	if n, err := o.WriteString("abc"); n != 2 || err != errStop {
+		t.Errorf("expecting to write 2 bytes with errStop, wrote %d with %v", n, err)
+	}
+
+	o = NewOverflowBuffer(2, OnOverflow(func(*OverflowBuffer, int) error { return nil }))
+
+	if n, err := o.WriteString("abc"); n != 2 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 2 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if n, err = o.WriteAt([]byte("abc"), 0); n != 2 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 2 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	}
+}
+
+func TestOverflowNegativeSize(t *testing.T) {
+	o := NewOverflowBuffer(-1, OverflowError)
+
+	if n, err := o.Write([]byte("a")); n != 0 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 0 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	}
+}
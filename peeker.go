@@ -0,0 +1,209 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Peeker wraps an io.Reader, buffering as much data as is required to satisfy
+// calls to Peek.
+type Peeker struct {
+	r        io.Reader
+	buf      Buffer
+	err      error
+	max      int
+	mark     Mark
+	lastRead int
+}
+
+const (
+	lastReadNone = iota
+	lastReadByte
+	lastReadRune
+)
+
+// NewPeeker creates a Peeker that reads from the given reader.
+func NewPeeker(r io.Reader) *Peeker {
+	return &Peeker{r: r}
+}
+
+// NewLimitedPeeker creates a Peeker that will not look more than the given
+// number of bytes ahead, returning a *LookaheadError when asked to.
+func NewLimitedPeeker(r io.Reader, lookahead int) *Peeker {
+	return &Peeker{r: r, max: lookahead}
+}
+
+// Buffered returns the number of bytes that have been read from the underlying
+// reader, but not yet consumed.
+func (p *Peeker) Buffered() int {
+	return len(p.buf)
+}
+
+// Peek returns the next n bytes without advancing the position, reading from
+// the underlying reader as necessary.
+//
+// If fewer than n bytes are available, the available bytes are returned along
+// with the error that stopped the reading.
+func (p *Peeker) Peek(n int) ([]byte, error) {
+	p.lastRead = lastReadNone
+
+	if n < 0 {
+		return nil, opError("peek", 0, ErrInvalidCount)
+	} else if p.max > 0 && n > p.max {
+		return nil, &LookaheadError{Max: p.max, Requested: n}
+	}
+
+	for len(p.buf) < n && p.err == nil {
+		p.fill(n - len(p.buf))
+	}
+
+	if len(p.buf) < n {
+		return p.buf, p.err
+	}
+
+	return p.buf[:n], nil
+}
+
+// Read is an implementation of the io.Reader interface.
+func (p *Peeker) Read(b []byte) (int, error) {
+	p.lastRead = lastReadNone
+
+	if len(b) == 0 {
+		return 0, nil
+	}
+
+	if len(p.buf) == 0 {
+		if p.err != nil {
+			return 0, p.readErr()
+		}
+
+		p.fill(len(b))
+	}
+
+	if len(p.buf) == 0 {
+		return 0, p.readErr()
+	}
+
+	return p.buf.Read(b)
+}
+
+// ReadByte is an implementation of the io.ByteReader interface.
+func (p *Peeker) ReadByte() (byte, error) {
+	p.lastRead = lastReadNone
+
+	for len(p.buf) == 0 {
+		if p.err != nil {
+			return 0, p.readErr()
+		}
+
+		p.fill(1)
+	}
+
+	p.mark = p.buf.Mark()
+	p.lastRead = lastReadByte
+
+	return p.buf.ReadByte()
+}
+
+// UnreadByte is an implementation of the io.ByteScanner interface.
+func (p *Peeker) UnreadByte() error {
+	if p.lastRead == lastReadNone {
+		return ErrInvalidUnreadByte
+	}
+
+	if p.lastRead == lastReadRune {
+		p.mark.data = p.mark.data[len(p.mark.data)-len(p.buf)-1:]
+	}
+
+	p.lastRead = lastReadNone
+
+	return p.buf.ResetTo(p.mark)
+}
+
+// ReadRune is an implementation of the io.RuneReader interface.
+func (p *Peeker) ReadRune() (rune, int, error) {
+	p.lastRead = lastReadNone
+
+	for len(p.buf) < utf8.UTFMax && !utf8.FullRune(p.buf) && p.err == nil {
+		p.fill(utf8.UTFMax - len(p.buf))
+	}
+
+	if len(p.buf) == 0 {
+		return 0, 0, p.readErr()
+	}
+
+	p.mark = p.buf.Mark()
+	p.lastRead = lastReadRune
+
+	return p.buf.ReadRune()
+}
+
+// UnreadRune is an implementation of the io.RuneScanner interface.
+func (p *Peeker) UnreadRune() error {
+	if p.lastRead != lastReadRune {
+		return ErrInvalidUnreadRune
+	}
+
+	p.lastRead = lastReadNone
+
+	return p.buf.ResetTo(p.mark)
+}
+
+// Discard skips the next n bytes, returning the number of bytes discarded.
+func (p *Peeker) Discard(n int) (int, error) {
+	p.lastRead = lastReadNone
+
+	var d int
+
+	for d < n {
+		if len(p.buf) == 0 {
+			if p.err != nil {
+				return d, p.readErr()
+			}
+
+			p.fill(n - d)
+
+			continue
+		}
+
+		m := min(n-d, len(p.buf))
+		p.buf = p.buf[m:]
+		d += m
+	}
+
+	return d, nil
+}
+
+func (p *Peeker) fill(n int) {
+	p.buf.reserve(max(n, minRead))
+
+	m, err := p.r.Read(p.buf[len(p.buf):cap(p.buf)])
+	p.buf = p.buf[:len(p.buf)+m]
+	p.err = err
+}
+
+func (p *Peeker) readErr() error {
+	if p.err == nil {
+		return io.EOF
+	}
+
+	return p.err
+}
+
+// LookaheadError is returned from a Peeker when asked to look further ahead
+// than its maximum.
+type LookaheadError struct {
+	Max, Requested int
+}
+
+// Error implements the error interface.
+func (l *LookaheadError) Error() string {
+	return "lookahead of " + strconv.Itoa(l.Requested) + " bytes exceeds maximum of " + strconv.Itoa(l.Max)
+}
+
+// Errors.
+var (
+	ErrInvalidUnreadRune = errors.New("invalid UnreadRune, no rune read")
+)
@@ -0,0 +1,81 @@
+package memio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var (
+	_ io.Reader      = new(Peeker)
+	_ io.ByteScanner = new(Peeker)
+	_ io.RuneScanner = new(Peeker)
+)
+
+func TestPeeker(t *testing.T) {
+	data := strings.Repeat("0123456789", 1000) + "€"
+	p := NewPeeker(iotest.OneByteReader(strings.NewReader(data)))
+
+	if b, err := p.Peek(5000); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if string(b) != data[:5000] {
+		t.Fatalf("peeked data does not match")
+	} else if n, err := p.Discard(9999); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if n != 9999 {
+		t.Fatalf("expecting to discard 9999 bytes, discarded %d", n)
+	} else if c, err := p.ReadByte(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if c != '9' {
+		t.Fatalf("expecting byte %q, got %q", '9', c)
+	} else if err = p.UnreadByte(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if err = p.UnreadByte(); err != ErrInvalidUnreadByte {
+		t.Fatalf("expecting ErrInvalidUnreadByte, got %v", err)
+	} else if c, err = p.ReadByte(); c != '9' {
+		t.Fatalf("expecting byte %q, got %q", '9', c)
+	} else if r, s, err := p.ReadRune(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if r != '€' || s != 3 {
+		t.Fatalf("expecting rune %q of size 3, got %q of size %d", '€', r, s)
+	} else if err = p.UnreadRune(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if b, err := p.Peek(4); err != io.EOF {
+		t.Fatalf("expecting io.EOF, got %v", err)
+	} else if string(b) != "€" {
+		t.Fatalf("expecting %q, got %q", "€", b)
+	}
+
+	p.ReadRune()
+
+	if err := p.UnreadByte(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var buf bytes.Buffer
+
+	if _, err := io.Copy(&buf, p); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if buf.String() != "\xac" {
+		t.Fatalf("expecting %q, got %q", "\xac", buf.String())
+	}
+}
+
+func TestLimitedPeeker(t *testing.T) {
+	p := NewLimitedPeeker(strings.NewReader("Hello, World!"), 5)
+
+	var le *LookaheadError
+
+	if b, err := p.Peek(5); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(b) != "Hello" {
+		t.Errorf("expecting %q, got %q", "Hello", b)
+	} else if _, err = p.Peek(6); !errors.As(err, &le) {
+		t.Errorf("expecting LookaheadError, got %v", err)
+	} else if le.Max != 5 || le.Requested != 6 {
+		t.Errorf("expecting max 5 and requested 6, got %d and %d", le.Max, le.Requested)
+	}
+}
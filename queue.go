@@ -0,0 +1,391 @@
+package memio
+
+import (
+	"io"
+	"iter"
+)
+
+// Queue is a generic version of Buffer, consuming elements from the front of
+// the slice and appending them to the back.
+type Queue[T any] []T
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	return len(*q)
+}
+
+// Push adds an element to the back of the queue.
+func (q *Queue[T]) Push(v T) {
+	*q = append(*q, v)
+}
+
+// PushSlice adds the elements of the slice to the back of the queue.
+func (q *Queue[T]) PushSlice(vs []T) int {
+	*q = append(*q, vs...)
+
+	return len(vs)
+}
+
+// PushFrom adds all of the elements produced by the iterator to the back of
+// the queue, returning the number of elements added.
+func (q *Queue[T]) PushFrom(seq iter.Seq[T]) int {
+	var n int
+
+	for v := range seq {
+		*q = append(*q, v)
+		n++
+	}
+
+	return n
+}
+
+// Pop removes and returns the element at the front of the queue.
+func (q *Queue[T]) Pop() (T, error) {
+	var zero T
+
+	if len(*q) == 0 {
+		return zero, io.EOF
+	}
+
+	v := (*q)[0]
+	(*q)[0] = zero
+	*q = (*q)[1:]
+
+	return v, nil
+}
+
+// PopInto removes elements from the front of the queue, copying them into the
+// given slice.
+func (q *Queue[T]) PopInto(p []T) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if len(*q) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, *q)
+
+	clear((*q)[:n])
+
+	*q = (*q)[n:]
+
+	return n, nil
+}
+
+// PopTo removes elements from the front of the queue, passing each to the
+// given func until either the queue is empty or the func returns false.
+//
+// Returns the number of elements removed.
+func (q *Queue[T]) PopTo(yield func(T) bool) int {
+	var n int
+
+	for len(*q) > 0 {
+		v, _ := q.Pop()
+		n++
+
+		if !yield(v) {
+			break
+		}
+	}
+
+	return n
+}
+
+// Peek returns the next n elements without removing them from the queue.
+func (q *Queue[T]) Peek(n int) ([]T, error) {
+	if n < 0 {
+		return nil, opError("peek", 0, ErrInvalidCount)
+	} else if n > len(*q) {
+		return *q, io.EOF
+	}
+
+	return (*q)[:n], nil
+}
+
+// Compact moves the elements of the queue into a new, exactly sized, slice,
+// releasing the memory used by previously consumed elements.
+func (q *Queue[T]) Compact() {
+	*q = append(make(Queue[T], 0, len(*q)), *q...)
+}
+
+// Close clears the queue.
+func (q *Queue[T]) Close() error {
+	*q = nil
+
+	return nil
+}
+
+// LimitedQueue is a generic version of LimitedBuffer, limiting the number of
+// elements in the queue to the capacity of the slice.
+type LimitedQueue[T any] []T
+
+// Len returns the number of elements in the queue.
+func (q *LimitedQueue[T]) Len() int {
+	return len(*q)
+}
+
+// Push adds an element to the back of the queue.
+func (q *LimitedQueue[T]) Push(v T) error {
+	if len(*q) == cap(*q) {
+		return io.ErrShortWrite
+	}
+
+	*q = append(*q, v)
+
+	return nil
+}
+
+// PushSlice adds the elements of the slice to the back of the queue, up to the
+// capacity of the queue.
+func (q *LimitedQueue[T]) PushSlice(vs []T) (int, error) {
+	var err error
+
+	if left := cap(*q) - len(*q); len(vs) > left {
+		vs = vs[:left]
+		err = io.ErrShortWrite
+	}
+
+	*q = append(*q, vs...)
+
+	return len(vs), err
+}
+
+// PushFrom adds the elements produced by the iterator to the back of the queue,
+// stopping when the queue is full. Returns the number of elements added.
+func (q *LimitedQueue[T]) PushFrom(seq iter.Seq[T]) int {
+	var n int
+
+	if len(*q) == cap(*q) {
+		return 0
+	}
+
+	for v := range seq {
+		*q = append(*q, v)
+		n++
+
+		if len(*q) == cap(*q) {
+			break
+		}
+	}
+
+	return n
+}
+
+// Pop removes and returns the element at the front of the queue.
+func (q *LimitedQueue[T]) Pop() (T, error) {
+	return (*Queue[T])(q).Pop()
+}
+
+// PopInto removes elements from the front of the queue, copying them into the
+// given slice.
+func (q *LimitedQueue[T]) PopInto(p []T) (int, error) {
+	return (*Queue[T])(q).PopInto(p)
+}
+
+// PopTo removes elements from the front of the queue, passing each to the
+// given func until either the queue is empty or the func returns false.
+//
+// Returns the number of elements removed.
+func (q *LimitedQueue[T]) PopTo(yield func(T) bool) int {
+	return (*Queue[T])(q).PopTo(yield)
+}
+
+// Peek returns the next n elements without removing them from the queue.
+func (q *LimitedQueue[T]) Peek(n int) ([]T, error) {
+	return (*Queue[T])(q).Peek(n)
+}
+
+// Compact moves the elements of the queue into a new slice with the same
+// remaining capacity, releasing the memory used by previously consumed
+// elements.
+func (q *LimitedQueue[T]) Compact() {
+	*q = append(make(LimitedQueue[T], 0, cap(*q)), *q...)
+}
+
+// Close clears the queue.
+func (q *LimitedQueue[T]) Close() error {
+	*q = nil
+
+	return nil
+}
+
+// RingQueue is a queue that stores its elements in a circular buffer, reusing
+// the space of consumed elements without reallocating. The buffer is grown
+// when full.
+type RingQueue[T any] struct {
+	data         []T
+	head, length int
+}
+
+// NewRingQueue creates a RingQueue with space for the given number of elements.
+// A negative size is treated as zero.
+func NewRingQueue[T any](size int) *RingQueue[T] {
+	return &RingQueue[T]{data: make([]T, max(size, 0))}
+}
+
+// Len returns the number of elements in the queue.
+func (r *RingQueue[T]) Len() int {
+	return r.length
+}
+
+// Push adds an element to the back of the queue.
+func (r *RingQueue[T]) Push(v T) {
+	r.grow(1)
+
+	r.data[(r.head+r.length)%len(r.data)] = v
+	r.length++
+}
+
+// PushSlice adds the elements of the slice to the back of the queue.
+func (r *RingQueue[T]) PushSlice(vs []T) int {
+	if len(vs) == 0 {
+		return 0
+	}
+
+	r.grow(len(vs))
+
+	tail := (r.head + r.length) % len(r.data)
+	n := copy(r.data[tail:], vs)
+
+	copy(r.data, vs[n:])
+
+	r.length += len(vs)
+
+	return len(vs)
+}
+
+// PushFrom adds all of the elements produced by the iterator to the back of
+// the queue, returning the number of elements added.
+func (r *RingQueue[T]) PushFrom(seq iter.Seq[T]) int {
+	var n int
+
+	for v := range seq {
+		r.Push(v)
+		n++
+	}
+
+	return n
+}
+
+// Pop removes and returns the element at the front of the queue.
+func (r *RingQueue[T]) Pop() (T, error) {
+	var zero T
+
+	if r.length == 0 {
+		return zero, io.EOF
+	}
+
+	v := r.data[r.head]
+	r.data[r.head] = zero
+	r.head = (r.head + 1) % len(r.data)
+	r.length--
+
+	return v, nil
+}
+
+// PopInto removes elements from the front of the queue, copying them into the
+// given slice.
+func (r *RingQueue[T]) PopInto(p []T) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if r.length == 0 {
+		return 0, io.EOF
+	}
+
+	if len(p) > r.length {
+		p = p[:r.length]
+	}
+
+	n := copy(p, r.data[r.head:])
+
+	clear(r.data[r.head : r.head+n])
+
+	m := copy(p[n:], r.data)
+
+	clear(r.data[:m])
+
+	r.head = (r.head + len(p)) % len(r.data)
+	r.length -= len(p)
+
+	return len(p), nil
+}
+
+// PopTo removes elements from the front of the queue, passing each to the
+// given func until either the queue is empty or the func returns false.
+//
+// Returns the number of elements removed.
+func (r *RingQueue[T]) PopTo(yield func(T) bool) int {
+	var n int
+
+	for r.length > 0 {
+		v, _ := r.Pop()
+		n++
+
+		if !yield(v) {
+			break
+		}
+	}
+
+	return n
+}
+
+// Peek returns the next n elements without removing them from the queue.
+//
+// If the elements wrap around the end of the buffer, the queue is first
+// compacted so that they are contiguous.
+func (r *RingQueue[T]) Peek(n int) ([]T, error) {
+	var err error
+
+	if n < 0 {
+		return nil, opError("peek", 0, ErrInvalidCount)
+	} else if n > r.length {
+		n = r.length
+		err = io.EOF
+	}
+
+	if r.head+n > len(r.data) {
+		r.Compact()
+	}
+
+	return r.data[r.head : r.head+n], err
+}
+
+// Compact moves the elements of the queue to the start of the buffer.
+func (r *RingQueue[T]) Compact() {
+	if r.head == 0 {
+		return
+	}
+
+	data := make([]T, len(r.data))
+	n := copy(data, r.data[r.head:min(r.head+r.length, len(r.data))])
+
+	copy(data[n:], r.data[:r.length-n])
+
+	r.data = data
+	r.head = 0
+}
+
+// Close clears the queue.
+func (r *RingQueue[T]) Close() error {
+	r.data = nil
+	r.head = 0
+	r.length = 0
+
+	return nil
+}
+
+func (r *RingQueue[T]) grow(n int) {
+	if r.length+n <= len(r.data) {
+		return
+	}
+
+	size := max(len(r.data)<<1, r.length+n)
+
+	r.Compact()
+
+	r.data = append(r.data, make([]T, size-len(r.data))...)
+}
@@ -0,0 +1,129 @@
+package memio
+
+import (
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestQueue(t *testing.T) {
+	var q Queue[int]
+
+	q.Push(1)
+	q.PushSlice([]int{2, 3, 4})
+	q.PushFrom(slices.Values([]int{5, 6}))
+
+	buf := make([]int, 2)
+
+	if v, err := q.Pop(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if v != 1 {
+		t.Errorf("expecting 1, got %d", v)
+	} else if n, err := q.PopInto(buf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(buf[:n], []int{2, 3}) {
+		t.Errorf("expecting [2 3], got %v", buf[:n])
+	} else if p, err := q.Peek(4); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if !reflect.DeepEqual(p, []int{4, 5, 6}) {
+		t.Errorf("expecting [4 5 6], got %v", p)
+	}
+
+	q.Compact()
+
+	if cap(q) != 3 {
+		t.Errorf("expecting capacity 3, got %d", cap(q))
+	}
+
+	var got []int
+
+	if n := q.PopTo(func(v int) bool {
+		got = append(got, v)
+
+		return v < 5
+	}); n != 2 {
+		t.Errorf("expecting to pop 2 elements, popped %d", n)
+	} else if !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Errorf("expecting [4 5], got %v", got)
+	} else if q.Len() != 1 {
+		t.Errorf("expecting length 1, got %d", q.Len())
+	}
+}
+
+func TestLimitedQueue(t *testing.T) {
+	q := make(LimitedQueue[string], 0, 3)
+
+	if err := q.Push("a"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err := q.PushSlice([]string{"b", "c", "d"}); err != io.ErrShortWrite {
+		t.Errorf("expecting io.ErrShortWrite, got %v", err)
+	} else if n != 2 {
+		t.Errorf("expecting to push 2 elements, pushed %d", n)
+	} else if err = q.Push("e"); err != io.ErrShortWrite {
+		t.Errorf("expecting io.ErrShortWrite, got %v", err)
+	} else if v, err := q.Pop(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if v != "a" {
+		t.Errorf("expecting %q, got %q", "a", v)
+	} else if n := q.PushFrom(slices.Values([]string{"f"})); n != 0 {
+		t.Errorf("expecting to push 0 elements, pushed %d", n)
+	}
+
+	q.Pop()
+	q.Compact()
+
+	if cap(q) != 1 {
+		t.Errorf("expecting capacity 1, got %d", cap(q))
+	} else if !reflect.DeepEqual([]string(q), []string{"c"}) {
+		t.Errorf("expecting [c], got %v", q)
+	}
+}
+
+func TestRingQueue(t *testing.T) {
+	r := NewRingQueue[int](4)
+
+	r.PushSlice([]int{1, 2, 3})
+	r.Pop()
+	r.Pop()
+	r.PushSlice([]int{4, 5, 6})
+
+	if r.Len() != 4 {
+		t.Errorf("expecting length 4, got %d", r.Len())
+	} else if len(r.data) != 4 {
+		t.Errorf("expecting buffer size 4, got %d", len(r.data))
+	} else if p, err := r.Peek(4); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(p, []int{3, 4, 5, 6}) {
+		t.Errorf("expecting [3 4 5 6], got %v", p)
+	}
+
+	r.Pop()
+	r.PushFrom(slices.Values([]int{7, 8, 9}))
+
+	buf := make([]int, 10)
+
+	if len(r.data) != 8 {
+		t.Errorf("expecting buffer size 8, got %d", len(r.data))
+	} else if n, err := r.PopInto(buf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(buf[:n], []int{4, 5, 6, 7, 8, 9}) {
+		t.Errorf("expecting [4 5 6 7 8 9], got %v", buf[:n])
+	} else if _, err = r.Pop(); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	}
+
+	var z RingQueue[int]
+
+	if n := z.PushSlice(nil); n != 0 {
+		t.Errorf("expecting to push 0 elements, pushed %d", n)
+	} else if n = NewRingQueue[int](0).PushSlice([]int{1, 2}); n != 2 {
+		t.Errorf("expecting to push 2 elements, pushed %d", n)
+	} else if n = z.PushSlice([]int{1}); n != 1 {
+		t.Errorf("expecting to push 1 element, pushed %d", n)
+	} else if r = NewRingQueue[int](-1); r.Len() != 0 {
+		t.Errorf("expecting length 0, got %d", r.Len())
+	} else if r.Push(1); r.Len() != 1 {
+		t.Errorf("expecting length 1, got %d", r.Len())
+	}
+}
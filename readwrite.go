@@ -3,6 +3,7 @@ package memio
 import (
 	"errors"
 	"io"
+	"unicode/utf8"
 )
 
 // ReadWriteMem is a combination of both the ReadMem and WriteMem types,
@@ -15,16 +16,18 @@ type ReadWriteMem struct {
 // io.Writer, io.Seeker, io.ReaderAt, io.ByteReader, io.WriterTo, io.WriterAt,
 // io.ByteWriter and io.ReaderFrom.
 func OpenMem(data *[]byte) *ReadWriteMem {
-	return &ReadWriteMem{WriteMem{data, 0}}
+	return &ReadWriteMem{WriteMem{data: data}}
 }
 
 // Peek reads the next n bytes without advancing the position.
 func (b *ReadWriteMem) Peek(n int) ([]byte, error) {
 	if b.data == nil {
-		return nil, ErrClosed
+		return nil, opError("peek", int64(b.pos), ErrClosed)
+	} else if n < 0 {
+		return nil, opError("peek", int64(b.pos), ErrInvalidCount)
 	} else if b.pos >= len(*b.data) {
 		return nil, io.EOF
-	} else if b.pos+n > len(*b.data) {
+	} else if n > len(*b.data)-b.pos {
 		return (*b.data)[b.pos:], io.EOF
 	}
 
@@ -34,7 +37,7 @@ func (b *ReadWriteMem) Peek(n int) ([]byte, error) {
 // Read is an implementation of the io.Reader interface.
 func (b *ReadWriteMem) Read(p []byte) (int, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("read", int64(b.pos), ErrClosed)
 	} else if b.pos >= len(*b.data) {
 		return 0, io.EOF
 	}
@@ -48,7 +51,7 @@ func (b *ReadWriteMem) Read(p []byte) (int, error) {
 // ReadByte is an implementation of the io.ByteReader interface.
 func (b *ReadWriteMem) ReadByte() (byte, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("readbyte", int64(b.pos), ErrClosed)
 	} else if b.pos >= len(*b.data) {
 		return 0, io.EOF
 	}
@@ -59,10 +62,24 @@ func (b *ReadWriteMem) ReadByte() (byte, error) {
 	return c, nil
 }
 
+// ReadRune is an implementation of the io.RuneReader interface.
+func (b *ReadWriteMem) ReadRune() (rune, int, error) {
+	if b.data == nil {
+		return 0, 0, opError("readrune", int64(b.pos), ErrClosed)
+	} else if b.pos >= len(*b.data) {
+		return 0, 0, io.EOF
+	}
+
+	r, n := utf8.DecodeRune((*b.data)[b.pos:])
+	b.pos += n
+
+	return r, n, nil
+}
+
 // UnreadByte implements the io.ByteScanner interface.
 func (b *ReadWriteMem) UnreadByte() error {
 	if b.data == nil {
-		return ErrClosed
+		return opError("unreadbyte", int64(b.pos), ErrClosed)
 	}
 
 	if b.pos > 0 {
@@ -76,26 +93,33 @@ func (b *ReadWriteMem) UnreadByte() error {
 // ReadAt is an implementation of the io.ReaderAt interface.
 func (b *ReadWriteMem) ReadAt(p []byte, off int64) (int, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("readat", off, ErrClosed)
+	} else if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
 	} else if off >= int64(len(*b.data)) {
 		return 0, io.EOF
 	}
 
-	return copy(p, (*b.data)[off:]), nil
+	n := copy(p, (*b.data)[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
 }
 
 // WriteTo is an implementation of the io.WriterTo interface.
 func (b *ReadWriteMem) WriteTo(f io.Writer) (int64, error) {
 	if b.data == nil {
-		return 0, ErrClosed
+		return 0, opError("writeto", int64(b.pos), ErrClosed)
 	} else if b.pos >= len(*b.data) {
-		return 0, io.EOF
+		return 0, nil
 	}
 
-	n, err := f.Write((*b.data)[b.pos:])
-	b.pos = len(*b.data)
+	n, err := writeAligned(f, (*b.data)[b.pos:], b.align)
+	b.pos += int(n)
 
-	return int64(n), err
+	return n, err
 }
 
 // Errors.
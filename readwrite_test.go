@@ -1,6 +1,7 @@
 package memio
 
 import (
+	"errors"
 	"io"
 	"testing"
 )
@@ -138,9 +139,9 @@ func TestReadWriteWrite(t *testing.T) {
 		t.Errorf("expecting %q, got %q", "Edwardo", string(data))
 	} else if err = writer.Close(); err != nil {
 		t.Errorf("unexpected error: %s", err)
-	} else if _, err = writer.Seek(0, 0); err != ErrClosed {
+	} else if _, err = writer.Seek(0, 0); !errors.Is(err, ErrClosed) {
 		t.Errorf("expecting close error")
-	} else if _, err = writer.Write([]byte("Beep")); err != ErrClosed {
+	} else if _, err = writer.Write([]byte("Beep")); !errors.Is(err, ErrClosed) {
 		t.Errorf("expecting close error")
 	}
 }
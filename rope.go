@@ -0,0 +1,366 @@
+package memio
+
+import (
+	"io"
+)
+
+const ropeChunkSize = 4096
+
+type ropeNode struct {
+	left, right *ropeNode
+	data        []byte
+	length      int
+	height      int
+}
+
+// Rope is a balanced tree of byte chunks, allowing efficient edits to large
+// amounts of data.
+//
+// The nodes of a Rope are never modified once created, so a Rope can be cloned
+// cheaply, with the clone sharing all of its data with the original.
+//
+// The zero value is an empty Rope ready for use.
+type Rope struct {
+	root *ropeNode
+}
+
+// NewRope creates a Rope containing a copy of the given data.
+func NewRope(data []byte) *Rope {
+	return &Rope{root: buildRope(data)}
+}
+
+// Len returns the length of the data in the Rope.
+func (r *Rope) Len() int {
+	if r.root == nil {
+		return 0
+	}
+
+	return r.root.length
+}
+
+// Clone returns a copy of the Rope that shares its underlying data.
+func (r *Rope) Clone() *Rope {
+	return &Rope{root: r.root}
+}
+
+// Insert inserts a copy of the given bytes at the given offset.
+func (r *Rope) Insert(off int, p []byte) error {
+	if off < 0 || off > r.Len() {
+		return opError("insert", int64(off), ErrInvalidOffset)
+	}
+
+	left, right := splitRope(r.root, off)
+	r.root = joinRope(joinRope(left, buildRope(p)), right)
+
+	return nil
+}
+
+// Delete removes n bytes starting at the given offset.
+func (r *Rope) Delete(off, n int) error {
+	if off < 0 || n < 0 || off > r.Len() || n > r.Len()-off {
+		return opError("delete", int64(off), ErrInvalidOffset)
+	}
+
+	left, rest := splitRope(r.root, off)
+	_, right := splitRope(rest, n)
+	r.root = joinRope(left, right)
+
+	return nil
+}
+
+// Concat appends the contents of the given Rope, sharing its data.
+func (r *Rope) Concat(s *Rope) {
+	r.root = joinRope(r.root, s.root)
+}
+
+// Split truncates the Rope at the given offset, returning a new Rope that
+// contains the removed data.
+func (r *Rope) Split(off int) (*Rope, error) {
+	if off < 0 || off > r.Len() {
+		return nil, opError("split", int64(off), ErrInvalidOffset)
+	}
+
+	var right *ropeNode
+
+	r.root, right = splitRope(r.root, off)
+
+	return &Rope{root: right}, nil
+}
+
+// Index returns the byte at the given offset.
+func (r *Rope) Index(off int) (byte, error) {
+	if off < 0 || off >= r.Len() {
+		return 0, opError("index", int64(off), ErrInvalidOffset)
+	}
+
+	n := r.root
+
+	for n.data == nil {
+		if off < n.left.length {
+			n = n.left
+		} else {
+			off -= n.left.length
+			n = n.right
+		}
+	}
+
+	return n.data[off], nil
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (r *Rope) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= int64(r.Len()) {
+		return 0, io.EOF
+	}
+
+	n := readRope(r.root, p, int(off))
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// WriteTo is an implementation of the io.WriterTo interface.
+//
+// The whole contents of the Rope is written, and the Rope is unchanged.
+func (r *Rope) WriteTo(w io.Writer) (int64, error) {
+	return writeRope(r.root, w)
+}
+
+// Reader returns an io.ReadSeeker over a snapshot of the current contents of
+// the Rope. Later changes to the Rope will not be seen by the reader.
+func (r *Rope) Reader() *RopeReader {
+	return &RopeReader{rope: Rope{root: r.root}}
+}
+
+// RopeReader is a read-only view of a Rope that implements io.Reader,
+// io.Seeker, io.ReaderAt and io.WriterTo.
+type RopeReader struct {
+	rope Rope
+	pos  int64
+}
+
+// Read is an implementation of the io.Reader interface.
+func (r *RopeReader) Read(p []byte) (int, error) {
+	if r.pos >= int64(r.rope.Len()) {
+		return 0, io.EOF
+	}
+
+	n := readRope(r.rope.root, p, int(r.pos))
+	r.pos += int64(n)
+
+	return n, nil
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (r *RopeReader) ReadAt(p []byte, off int64) (int, error) {
+	return r.rope.ReadAt(p, off)
+}
+
+// Seek is an implementation of the io.Seeker interface.
+func (r *RopeReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += r.pos
+	case seekEnd:
+		offset += int64(r.rope.Len())
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	r.pos = offset
+
+	return offset, nil
+}
+
+// WriteTo is an implementation of the io.WriterTo interface.
+func (r *RopeReader) WriteTo(w io.Writer) (int64, error) {
+	if r.pos >= int64(r.rope.Len()) {
+		return 0, nil
+	}
+
+	_, rest := splitRope(r.rope.root, int(r.pos))
+	n, err := writeRope(rest, w)
+	r.pos += n
+
+	return n, err
+}
+
+func buildRope(data []byte) *ropeNode {
+	if len(data) == 0 {
+		return nil
+	} else if len(data) <= ropeChunkSize {
+		return newRopeLeaf(append(make([]byte, 0, len(data)), data...))
+	}
+
+	chunks := (len(data) + ropeChunkSize - 1) / ropeChunkSize
+	mid := (chunks / 2) * ropeChunkSize
+
+	return newRopeNode(buildRope(data[:mid]), buildRope(data[mid:]))
+}
+
+func newRopeLeaf(data []byte) *ropeNode {
+	return &ropeNode{data: data, length: len(data), height: 1}
+}
+
+func newRopeNode(left, right *ropeNode) *ropeNode {
+	h := left.height
+
+	if right.height > h {
+		h = right.height
+	}
+
+	return &ropeNode{
+		left:   left,
+		right:  right,
+		length: left.length + right.length,
+		height: h + 1,
+	}
+}
+
+func ropeHeight(n *ropeNode) int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func joinRope(left, right *ropeNode) *ropeNode {
+	if left == nil {
+		return right
+	} else if right == nil {
+		return left
+	} else if right.data != nil {
+		if n := appendRopeLeaf(left, right.data); n != nil {
+			return n
+		}
+	} else if left.data != nil {
+		if n := prependRopeLeaf(right, left.data); n != nil {
+			return n
+		}
+	}
+
+	if hl, hr := left.height, right.height; hl > hr+1 {
+		return balanceRope(left.left, joinRope(left.right, right))
+	} else if hr > hl+1 {
+		return balanceRope(joinRope(left, right.left), right.right)
+	}
+
+	return newRopeNode(left, right)
+}
+
+// appendRopeLeaf merges the data into the last leaf of the tree, if it fits.
+func appendRopeLeaf(n *ropeNode, data []byte) *ropeNode {
+	if n.data != nil {
+		if n.length+len(data) > ropeChunkSize {
+			return nil
+		}
+
+		return newRopeLeaf(append(append(make([]byte, 0, n.length+len(data)), n.data...), data...))
+	}
+
+	right := appendRopeLeaf(n.right, data)
+	if right == nil {
+		return nil
+	}
+
+	return newRopeNode(n.left, right)
+}
+
+// prependRopeLeaf merges the data into the first leaf of the tree, if it fits.
+func prependRopeLeaf(n *ropeNode, data []byte) *ropeNode {
+	if n.data != nil {
+		if n.length+len(data) > ropeChunkSize {
+			return nil
+		}
+
+		return newRopeLeaf(append(append(make([]byte, 0, n.length+len(data)), data...), n.data...))
+	}
+
+	left := prependRopeLeaf(n.left, data)
+	if left == nil {
+		return nil
+	}
+
+	return newRopeNode(left, n.right)
+}
+
+// balanceRope joins two trees whose heights differ by no more than two,
+// rotating as necessary to keep the result balanced.
+func balanceRope(left, right *ropeNode) *ropeNode {
+	if left.height > right.height+1 {
+		if ropeHeight(left.left) >= ropeHeight(left.right) {
+			return newRopeNode(left.left, newRopeNode(left.right, right))
+		}
+
+		return newRopeNode(newRopeNode(left.left, left.right.left), newRopeNode(left.right.right, right))
+	} else if right.height > left.height+1 {
+		if ropeHeight(right.right) >= ropeHeight(right.left) {
+			return newRopeNode(newRopeNode(left, right.left), right.right)
+		}
+
+		return newRopeNode(newRopeNode(left, right.left.left), newRopeNode(right.left.right, right.right))
+	}
+
+	return newRopeNode(left, right)
+}
+
+func splitRope(n *ropeNode, off int) (*ropeNode, *ropeNode) {
+	if n == nil || off <= 0 {
+		return nil, n
+	} else if off >= n.length {
+		return n, nil
+	} else if n.data != nil {
+		return newRopeLeaf(n.data[:off:off]), newRopeLeaf(n.data[off:])
+	} else if off < n.left.length {
+		left, right := splitRope(n.left, off)
+
+		return left, joinRope(right, n.right)
+	}
+
+	left, right := splitRope(n.right, off-n.left.length)
+
+	return joinRope(n.left, left), right
+}
+
+func readRope(n *ropeNode, p []byte, off int) int {
+	if n == nil || len(p) == 0 || off >= n.length {
+		return 0
+	} else if n.data != nil {
+		return copy(p, n.data[off:])
+	} else if off >= n.left.length {
+		return readRope(n.right, p, off-n.left.length)
+	}
+
+	m := readRope(n.left, p, off)
+
+	return m + readRope(n.right, p[m:], 0)
+}
+
+func writeRope(n *ropeNode, w io.Writer) (int64, error) {
+	if n == nil {
+		return 0, nil
+	} else if n.data != nil {
+		m, err := w.Write(n.data)
+
+		return int64(m), err
+	}
+
+	m, err := writeRope(n.left, w)
+	if err != nil {
+		return m, err
+	}
+
+	o, err := writeRope(n.right, w)
+
+	return m + o, err
+}
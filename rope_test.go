@@ -0,0 +1,136 @@
+package memio
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+var (
+	_ io.ReaderAt   = new(Rope)
+	_ io.WriterTo   = new(Rope)
+	_ io.ReadSeeker = new(RopeReader)
+	_ io.ReaderAt   = new(RopeReader)
+	_ io.WriterTo   = new(RopeReader)
+)
+
+func checkRope(t *testing.T, n *ropeNode) {
+	t.Helper()
+
+	if n == nil || n.data != nil {
+		return
+	}
+
+	if d := n.left.height - n.right.height; d < -1 || d > 1 {
+		t.Fatalf("unbalanced node: heights %d and %d", n.left.height, n.right.height)
+	} else if n.length != n.left.length+n.right.length {
+		t.Fatalf("invalid length: %d != %d + %d", n.length, n.left.length, n.right.length)
+	}
+
+	checkRope(t, n.left)
+	checkRope(t, n.right)
+}
+
+func TestRope(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	data := make([]byte, 50000)
+
+	rnd.Read(data)
+
+	r := NewRope(data)
+
+	var model []byte
+
+	model = append(model, data...)
+
+	for i := 0; i < 1000; i++ {
+		switch rnd.Intn(4) {
+		case 0, 1:
+			off := rnd.Intn(len(model) + 1)
+			p := make([]byte, rnd.Intn(10000))
+
+			rnd.Read(p)
+
+			if err := r.Insert(off, p); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			model = append(model[:off], append(p, model[off:]...)...)
+		case 2:
+			off := rnd.Intn(len(model) + 1)
+			n := rnd.Intn(len(model) - off + 1)
+
+			if err := r.Delete(off, n); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			model = append(model[:off], model[off+n:]...)
+		case 3:
+			off := rnd.Intn(len(model) + 1)
+			s, err := r.Split(off)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			checkRope(t, r.root)
+			checkRope(t, s.root)
+			r.Concat(s)
+		}
+
+		checkRope(t, r.root)
+
+		if r.Len() != len(model) {
+			t.Fatalf("op %d: expecting length %d, got %d", i, len(model), r.Len())
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if _, err := r.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	} else if !bytes.Equal(buf.Bytes(), model) {
+		t.Fatalf("rope contents do not match model")
+	}
+
+	for i := 0; i < 100; i++ {
+		off := rnd.Intn(len(model))
+
+		if c, err := r.Index(off); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		} else if c != model[off] {
+			t.Fatalf("at offset %d: expecting byte %d, got %d", off, model[off], c)
+		}
+	}
+}
+
+func TestRopeReader(t *testing.T) {
+	r := NewRope([]byte("Hello, World!"))
+	c := r.Clone()
+
+	r.Insert(5, []byte(" there"))
+
+	rr := c.Reader()
+	buf := make([]byte, 5)
+
+	if n, err := rr.Read(buf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != "Hello" {
+		t.Errorf("expecting %q, got %q", "Hello", buf[:n])
+	} else if _, err = rr.Seek(-6, seekEnd); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err = rr.Read(buf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != "World" {
+		t.Errorf("expecting %q, got %q", "World", buf[:n])
+	} else if n, err = r.ReadAt(buf, 5); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != " ther" {
+		t.Errorf("expecting %q, got %q", " ther", buf[:n])
+	} else if n, err = r.ReadAt(buf, 16); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if string(buf[:n]) != "ld!" {
+		t.Errorf("expecting %q, got %q", "ld!", buf[:n])
+	}
+}
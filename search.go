@@ -0,0 +1,378 @@
+package memio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+)
+
+const (
+	finderChunkSize = 32768
+	horspoolMinLen  = 64
+)
+
+// Index returns the absolute offset of the first instance of sep at or after
+// the current position, or -1 if it is not present. The position is not
+// changed.
+func (r ReadMem) Index(sep []byte) int64 {
+	pos, data := r.unread()
+
+	if n := indexFrom(data, 0, sep); n >= 0 {
+		return int64(pos) + n
+	}
+
+	return -1
+}
+
+// IndexAll returns the absolute offsets of all non-overlapping instances of
+// sep at or after the current position. The position is not changed.
+func (r ReadMem) IndexAll(sep []byte) []int64 {
+	pos, data := r.unread()
+	offsets := indexAllFrom(data, 0, sep)
+
+	for n := range offsets {
+		offsets[n] += int64(pos)
+	}
+
+	return offsets
+}
+
+// LastIndex returns the absolute offset of the last instance of sep at or after
+// the current position, or -1 if it is not present. The position is not
+// changed.
+func (r ReadMem) LastIndex(sep []byte) int64 {
+	pos, data := r.unread()
+
+	if n := lastIndexFrom(data, 0, sep); n >= 0 {
+		return int64(pos) + n
+	}
+
+	return -1
+}
+
+// pos returns the current position of the Reader, without losing the ability
+// to UnreadRune, which seeking would.
+func (r ReadMem) pos() int {
+	if r.Reader.Len() > 0 {
+		return int(r.Reader.Size()) - r.Reader.Len()
+	} else if r.Reader.UnreadRune() == nil {
+		r.Reader.ReadRune()
+
+		return int(r.Reader.Size())
+	}
+
+	pos, _ := r.Reader.Seek(0, seekCurr)
+
+	return int(pos)
+}
+
+// unread returns the current position and a copy of the unread bytes of the
+// Reader, without changing the position.
+func (r ReadMem) unread() (int, []byte) {
+	pos := r.pos()
+	data := make([]byte, r.Reader.Len())
+
+	r.Reader.ReadAt(data, int64(pos))
+
+	return pos, data
+}
+
+// Index returns the absolute offset of the first instance of sep at or after
+// the current position, or -1 if it is not present. The position is not
+// changed.
+func (b *ReadWriteMem) Index(sep []byte) int64 {
+	if b.data == nil {
+		return -1
+	}
+
+	return indexFrom(*b.data, b.pos, sep)
+}
+
+// IndexAll returns the absolute offsets of all non-overlapping instances of
+// sep at or after the current position. The position is not changed.
+func (b *ReadWriteMem) IndexAll(sep []byte) []int64 {
+	if b.data == nil {
+		return nil
+	}
+
+	return indexAllFrom(*b.data, b.pos, sep)
+}
+
+// LastIndex returns the absolute offset of the last instance of sep at or after
+// the current position, or -1 if it is not present. The position is not
+// changed.
+func (b *ReadWriteMem) LastIndex(sep []byte) int64 {
+	if b.data == nil {
+		return -1
+	}
+
+	return lastIndexFrom(*b.data, b.pos, sep)
+}
+
+func indexFrom(data []byte, pos int, sep []byte) int64 {
+	if pos > len(data) {
+		return -1
+	}
+
+	n := bytes.Index(data[pos:], sep)
+	if n < 0 {
+		return -1
+	}
+
+	return int64(pos + n)
+}
+
+func indexAllFrom(data []byte, pos int, sep []byte) []int64 {
+	var offsets []int64
+
+	for {
+		n := indexFrom(data, pos, sep)
+		if n < 0 {
+			return offsets
+		}
+
+		offsets = append(offsets, n)
+		pos = int(n) + len(sep)
+
+		if len(sep) == 0 {
+			pos++
+		}
+	}
+}
+
+func lastIndexFrom(data []byte, pos int, sep []byte) int64 {
+	if pos > len(data) {
+		return -1
+	}
+
+	n := bytes.LastIndex(data[pos:], sep)
+	if n < 0 {
+		return -1
+	}
+
+	return int64(pos + n)
+}
+
+// Finder searches a stream for non-overlapping instances of a pattern,
+// correctly finding matches that span multiple reads.
+//
+// Long patterns are searched for using the Boyer-Moore-Horspool algorithm.
+type Finder struct {
+	r       io.Reader
+	pattern []byte
+	skip    *[256]int
+	data    []byte
+	buf     Buffer
+	off     int64
+	err     error
+}
+
+// NewFinder creates a Finder that will search the given reader for the given
+// pattern.
+func NewFinder(r io.Reader, pattern []byte) *Finder {
+	f := &Finder{
+		r:       r,
+		pattern: pattern,
+	}
+
+	if len(pattern) >= horspoolMinLen {
+		f.skip = new([256]int)
+		last := len(pattern) - 1
+
+		for n := range f.skip {
+			f.skip[n] = len(pattern)
+		}
+
+		for n, c := range pattern[:last] {
+			f.skip[c] = last - n
+		}
+	}
+
+	return f
+}
+
+// Next returns the offset, relative to the start of the stream, of the next
+// instance of the pattern.
+//
+// Returns io.EOF when no further instances can be found.
+func (f *Finder) Next() (int64, error) {
+	if len(f.pattern) == 0 {
+		return -1, ErrEmptyPattern
+	}
+
+	for {
+		if n := f.index(f.buf); n >= 0 {
+			pos := f.off + int64(n)
+			f.discard(n + len(f.pattern))
+
+			return pos, nil
+		} else if f.err != nil {
+			err := f.err
+			if err == io.EOF {
+				f.discard(len(f.buf))
+			}
+
+			return -1, err
+		}
+
+		if keep := len(f.pattern) - 1; len(f.buf) > keep {
+			f.discard(len(f.buf) - keep)
+		}
+
+		f.fill()
+	}
+}
+
+func (f *Finder) discard(n int) {
+	f.buf = f.buf[n:]
+	f.off += int64(n)
+}
+
+func (f *Finder) fill() {
+	if cap(f.buf)-len(f.buf) < finderChunkSize {
+		if cap(f.data) < len(f.buf)+finderChunkSize {
+			f.data = make([]byte, 0, len(f.buf)+finderChunkSize)
+		}
+
+		f.buf = append(f.data[:0], f.buf...)
+	}
+
+	n, err := f.r.Read(f.buf[len(f.buf):cap(f.buf)])
+	f.buf = f.buf[:len(f.buf)+n]
+	f.err = err
+}
+
+func (f *Finder) index(data []byte) int {
+	if f.skip == nil {
+		return bytes.Index(data, f.pattern)
+	}
+
+	last := len(f.pattern) - 1
+
+	for n := 0; n+last < len(data); {
+		c := data[n+last]
+
+		if c == f.pattern[last] && bytes.Equal(data[n:n+last], f.pattern[:last]) {
+			return n
+		}
+
+		n += f.skip[c]
+	}
+
+	return -1
+}
+
+// ReplaceWriter is an io.WriteCloser that replaces all instances of one byte
+// sequence with another as data is written through it.
+//
+// Data that may be the start of a match is held back until it can be
+// determined otherwise, so Close must be called to write any remaining data.
+type ReplaceWriter struct {
+	w        io.Writer
+	old, new []byte
+	buf      Buffer
+}
+
+// NewReplaceWriter creates a ReplaceWriter that writes to w, replacing old with
+// new.
+func NewReplaceWriter(w io.Writer, old, new []byte) *ReplaceWriter {
+	return &ReplaceWriter{
+		w:   w,
+		old: old,
+		new: new,
+	}
+}
+
+// Write is an implementation of the io.Writer interface.
+//
+// If the underlying writer returns an error, the returned count is the number
+// of bytes of p that were written, and the rest of p is discarded.
+func (r *ReplaceWriter) Write(p []byte) (int, error) {
+	if len(r.old) == 0 {
+		return r.w.Write(p)
+	}
+
+	r.buf = append(r.buf, p...)
+
+	for {
+		n := bytes.Index(r.buf, r.old)
+		if n < 0 {
+			break
+		}
+
+		if m, err := r.w.Write(r.buf[:n]); err != nil {
+			return r.failed(len(p), m, err)
+		} else if _, err := r.w.Write(r.new); err != nil {
+			return r.failed(len(p), n, err)
+		}
+
+		r.buf = r.buf[n+len(r.old):]
+	}
+
+	if safe := len(r.buf) - len(r.old) + 1; safe > 0 {
+		if m, err := r.w.Write(r.buf[:safe]); err != nil {
+			return r.failed(len(p), m, err)
+		}
+
+		r.buf = r.buf[safe:]
+	}
+
+	return len(p), nil
+}
+
+// failed drops the n buffered bytes that were written, and any unwritten bytes
+// of the last write, of length l, returning the number of its bytes consumed.
+func (r *ReplaceWriter) failed(l, n int, err error) (int, error) {
+	r.buf = r.buf[min(max(n, 0), len(r.buf)):]
+	unwritten := min(len(r.buf), l)
+	r.buf = r.buf[:len(r.buf)-unwritten]
+
+	return l - unwritten, err
+}
+
+// Close writes any data that has been held back.
+func (r *ReplaceWriter) Close() error {
+	_, err := r.w.Write(r.buf)
+	r.buf = r.buf[:0]
+
+	return err
+}
+
+// FindRegexp searches for the regular expression from the current position of
+// the reader, returning the absolute start and end offsets of the first match,
+// or nil if there is no match.
+//
+// On a match, the reader is positioned at the end of the match; otherwise, it
+// is returned to its starting position.
+func FindRegexp(re *regexp.Regexp, r interface {
+	io.RuneReader
+	io.Seeker
+}) ([]int64, error) {
+	start, err := r.Seek(0, seekCurr)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := re.FindReaderIndex(r)
+	if loc == nil {
+		if _, err := r.Seek(start, seekSet); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	match := []int64{start + int64(loc[0]), start + int64(loc[1])}
+
+	if _, err := r.Seek(match[1], seekSet); err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+// Errors.
+var (
+	ErrEmptyPattern = errors.New("empty pattern")
+)
@@ -0,0 +1,208 @@
+package memio
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestIndex(t *testing.T) {
+	data := []byte("abcabcabc")
+	r := Open(data)
+	rw := OpenMem(&data)
+
+	r.Seek(1, seekSet)
+	rw.Seek(1, seekSet)
+
+	for _, s := range [...]interface {
+		Index([]byte) int64
+		IndexAll([]byte) []int64
+		LastIndex([]byte) int64
+	}{r, rw} {
+		if n := s.Index([]byte("abc")); n != 3 {
+			t.Errorf("expecting index 3, got %d", n)
+		} else if ns := s.IndexAll([]byte("abc")); !reflect.DeepEqual(ns, []int64{3, 6}) {
+			t.Errorf("expecting indexes [3 6], got %v", ns)
+		} else if n = s.LastIndex([]byte("ca")); n != 5 {
+			t.Errorf("expecting index 5, got %d", n)
+		} else if n = s.Index([]byte("d")); n != -1 {
+			t.Errorf("expecting index -1, got %d", n)
+		}
+	}
+
+	if pos, _ := r.Seek(0, seekCurr); pos != 1 {
+		t.Errorf("expecting position 1, got %d", pos)
+	} else if c, _, err := r.ReadRune(); c != 'b' || err != nil {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", 'b', c, err)
+	} else if n := r.Index([]byte("c")); n != 2 {
+		t.Errorf("expecting index 2, got %d", n)
+	} else if err = r.UnreadRune(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if _, err = r.Seek(-1, io.SeekEnd); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if c, _, err = r.ReadRune(); c != 'c' || err != nil {
+		t.Errorf("expecting to read %q with nil error, read %q with %v", 'c', c, err)
+	} else if n := r.Index([]byte("c")); n != -1 {
+		t.Errorf("expecting index -1, got %d", n)
+	} else if err = r.UnreadRune(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	r = ReadMem{bytes.NewReader(data)}
+
+	r.Reset([]byte("xyzxyz"))
+
+	if n := r.Index([]byte("z")); n != 2 {
+		t.Errorf("expecting index 2, got %d", n)
+	} else if n = r.LastIndex([]byte("x")); n != 3 {
+		t.Errorf("expecting index 3, got %d", n)
+	} else if pos, _ := r.Seek(-1, io.SeekEnd); pos != 5 {
+		t.Errorf("expecting position 5, got %d", pos)
+	} else if p, _ := r.Peek(1); string(p) != "z" {
+		t.Errorf("expecting to peek %q, got %q", "z", p)
+	}
+}
+
+func TestFinder(t *testing.T) {
+	long := strings.Repeat("0123456789", 10)
+
+	for n, test := range [...]struct {
+		Data, Pattern string
+		Expected      []int64
+	}{
+		{"Hello, World", "o", []int64{4, 8}},
+		{"aaaaa", "aa", []int64{0, 2}},
+		{"abc", "abcd", nil},
+		{"xx" + long + "x" + long, long, []int64{2, 103}},
+		{"xx" + long[:99] + "x" + long, long, []int64{102}},
+	} {
+		for m, r := range [...]io.Reader{
+			strings.NewReader(test.Data),
+			iotest.OneByteReader(strings.NewReader(test.Data)),
+		} {
+			f := NewFinder(r, []byte(test.Pattern))
+
+			var got []int64
+
+			for {
+				pos, err := f.Next()
+				if err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("test %d.%d: unexpected error: %s", n+1, m+1, err)
+				}
+
+				got = append(got, pos)
+			}
+
+			if !reflect.DeepEqual(got, test.Expected) {
+				t.Errorf("test %d.%d: expecting %v, got %v", n+1, m+1, test.Expected, got)
+			}
+		}
+	}
+
+	f := NewFinder(&cycleReader{data: strings.Repeat("x", finderChunkSize*2) + "needle"}, []byte("needle"))
+
+	f.Next()
+
+	if allocs := testing.AllocsPerRun(100, func() { f.Next() }); allocs != 0 {
+		t.Errorf("expecting no allocations when refilling, got %v", allocs)
+	}
+}
+
+func TestReplaceWriter(t *testing.T) {
+	const (
+		input    = "The cat sat on the cat mat, catcat."
+		expected = "The dog sat on the dog mat, dogdog."
+	)
+
+	var buf bytes.Buffer
+
+	w := NewReplaceWriter(&buf, []byte("cat"), []byte("dog"))
+
+	for n := range input {
+		w.Write([]byte{input[n]})
+	}
+
+	if err := w.Close(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if buf.String() != expected {
+		t.Errorf("expecting %q, got %q", expected, buf.String())
+	}
+}
+
+type cycleReader struct {
+	data string
+	pos  int
+}
+
+func (c *cycleReader) Read(p []byte) (int, error) {
+	n := copy(p, c.data[c.pos:])
+	c.pos = (c.pos + n) % len(c.data)
+
+	return n, nil
+}
+
+type failingWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+	if left := f.limit - f.Len(); len(p) > left {
+		f.Buffer.Write(p[:left])
+
+		return left, io.ErrShortWrite
+	}
+
+	return f.Buffer.Write(p)
+}
+
+func TestReplaceWriterError(t *testing.T) {
+	fw := &failingWriter{limit: 6}
+	w := NewReplaceWriter(fw, []byte("cat"), []byte("dog"))
+
+	if n, err := w.Write([]byte("ca")); n != 2 || err != nil {
+		t.Errorf("expecting to write 2 bytes with nil error, wrote %d with %v", n, err)
+	} else if n, err = w.Write([]byte("t, hat, cat")); n != 4 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 4 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if fw.String() != "dog, h" {
+		t.Errorf("expecting %q, got %q", "dog, h", fw.String())
+	} else if fw.limit = 100; w.Close() != nil {
+		t.Errorf("unexpected error closing")
+	} else if fw.String() != "dog, h" {
+		t.Errorf("expecting %q, got %q", "dog, h", fw.String())
+	}
+}
+
+func TestFindRegexp(t *testing.T) {
+	data := []byte("a1 b22 c333")
+	rw := OpenMem(&data)
+	re := regexp.MustCompile("[0-9]+")
+
+	rw.Seek(2, seekSet)
+
+	if loc, err := FindRegexp(re, rw); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(loc, []int64{4, 6}) {
+		t.Errorf("expecting [4 6], got %v", loc)
+	} else if loc, err = FindRegexp(re, rw); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if !reflect.DeepEqual(loc, []int64{8, 11}) {
+		t.Errorf("expecting [8 11], got %v", loc)
+	} else if loc, err = FindRegexp(re, rw); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if loc != nil {
+		t.Errorf("expecting no match, got %v", loc)
+	} else if _, err = rw.Seek(7, seekSet); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if loc, err = FindRegexp(regexp.MustCompile("x"), rw); err != nil || loc != nil {
+		t.Errorf("expecting no match with nil error, got %v with %v", loc, err)
+	} else if pos, _ := rw.Seek(0, seekCurr); pos != 7 {
+		t.Errorf("expecting position 7, got %d", pos)
+	}
+}
@@ -0,0 +1,181 @@
+package memio
+
+import (
+	"io"
+)
+
+// Section is a bounded, writable, view of part of a WriteMem, with its own
+// position.
+//
+// All offsets are relative to the start of the section, and writes that would
+// cross the end of the section are truncated and return io.ErrShortWrite.
+type Section struct {
+	mem       *WriteMem
+	off, n    int64
+	pos       int64
+	growsTail bool
+}
+
+// Section returns a view of the n bytes of the WriteMem starting at off.
+//
+// The section will not grow the WriteMem, so if the WriteMem is shorter than
+// off+n, the section is limited to the existing data.
+func (b *WriteMem) Section(off, n int64) *Section {
+	return &Section{mem: b, off: off, n: n}
+}
+
+// TailSection returns a view of the WriteMem starting at off and extending to
+// its end. Writes to the section may grow the WriteMem, but not the section
+// beyond max bytes; a negative max allows unlimited growth.
+func (b *WriteMem) TailSection(off, max int64) *Section {
+	return &Section{mem: b, off: off, n: max, growsTail: true}
+}
+
+// Size returns the current size of the section.
+func (s *Section) Size() int64 {
+	if s.mem.data == nil || s.off < 0 {
+		return 0
+	}
+
+	size := int64(len(*s.mem.data)) - s.off
+
+	if (!s.growsTail || s.n >= 0) && size > s.n {
+		size = s.n
+	}
+
+	if size < 0 {
+		return 0
+	}
+
+	return size
+}
+
+// Read is an implementation of the io.Reader interface.
+func (s *Section) Read(p []byte) (int, error) {
+	n, err := s.ReadAt(p, s.pos)
+	s.pos += int64(n)
+
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (s *Section) ReadAt(p []byte, off int64) (int, error) {
+	if s.mem.data == nil {
+		return 0, opError("readat", off, ErrClosed)
+	} else if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	}
+
+	size := s.Size()
+	if off >= size {
+		return 0, io.EOF
+	}
+
+	if left := size - off; int64(len(p)) > left {
+		n := copy(p, (*s.mem.data)[s.off+off:s.off+size])
+
+		return n, io.EOF
+	}
+
+	return copy(p, (*s.mem.data)[s.off+off:]), nil
+}
+
+// Write is an implementation of the io.Writer interface.
+func (s *Section) Write(p []byte) (int, error) {
+	n, err := s.WriteAt(p, s.pos)
+	s.pos += int64(n)
+
+	return n, err
+}
+
+// WriteAt is an implementation of the io.WriterAt interface.
+func (s *Section) WriteAt(p []byte, off int64) (int, error) {
+	if s.mem.data == nil {
+		return 0, opError("writeat", off, ErrClosed)
+	} else if off < 0 {
+		return 0, opError("writeat", off, ErrInvalidOffset)
+	}
+
+	var err error
+
+	if limit := s.limit(); limit >= 0 {
+		left := max(limit-off, 0)
+
+		if int64(len(p)) > left {
+			p = p[:left]
+			err = io.ErrShortWrite
+		}
+	}
+
+	if len(p) == 0 {
+		return 0, err
+	}
+
+	n, werr := s.mem.WriteAt(p, s.off+off)
+	if werr != nil {
+		return n, werr
+	}
+
+	return n, err
+}
+
+// limit returns the size to which writes to the section are restricted, or a
+// negative number when they are not.
+func (s *Section) limit() int64 {
+	if s.growsTail {
+		return s.n
+	}
+
+	return s.Size()
+}
+
+// Seek is an implementation of the io.Seeker interface.
+func (s *Section) Seek(offset int64, whence int) (int64, error) {
+	if s.mem.data == nil {
+		return 0, opError("seek", offset, ErrClosed)
+	}
+
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += s.pos
+	case seekEnd:
+		offset += s.Size()
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	s.pos = offset
+
+	return offset, nil
+}
+
+// Peek reads the next n bytes without advancing the position.
+func (s *Section) Peek(n int) ([]byte, error) {
+	if s.mem.data == nil {
+		return nil, opError("peek", s.pos, ErrClosed)
+	} else if n < 0 {
+		return nil, opError("peek", s.pos, ErrInvalidCount)
+	}
+
+	size := s.Size()
+	if s.pos >= size {
+		return nil, io.EOF
+	}
+
+	start := s.off + s.pos
+
+	if int64(n) > size-s.pos {
+		return (*s.mem.data)[start : s.off+size], io.EOF
+	}
+
+	return (*s.mem.data)[start : start+int64(n)], nil
+}
@@ -0,0 +1,81 @@
+package memio
+
+import (
+	"io"
+	"testing"
+)
+
+var (
+	_ io.ReadWriteSeeker = new(Section)
+	_ io.ReaderAt        = new(Section)
+	_ io.WriterAt        = new(Section)
+)
+
+func TestSection(t *testing.T) {
+	data := []byte("[header][body][trailer]")
+	w := Create(&data)
+	s := w.Section(9, 4)
+	buf := make([]byte, 10)
+
+	if n, err := s.Read(buf); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if string(buf[:n]) != "body" {
+		t.Errorf("expecting %q, got %q", "body", buf[:n])
+	} else if _, err = s.Seek(0, seekSet); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n, err = s.Write([]byte("BODY!")); err != io.ErrShortWrite {
+		t.Errorf("expecting io.ErrShortWrite, got %v", err)
+	} else if n != 4 {
+		t.Errorf("expecting to write 4 bytes, wrote %d", n)
+	} else if string(data) != "[header][BODY][trailer]" {
+		t.Errorf("expecting %q, got %q", "[header][BODY][trailer]", data)
+	} else if n, err = s.WriteAt([]byte("x"), 4); err != io.ErrShortWrite {
+		t.Errorf("expecting io.ErrShortWrite, got %v", err)
+	} else if n != 0 {
+		t.Errorf("expecting to write 0 bytes, wrote %d", n)
+	} else if pos, err := s.Seek(-2, seekEnd); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if pos != 2 {
+		t.Errorf("expecting position 2, got %d", pos)
+	} else if p, err := s.Peek(5); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	} else if string(p) != "DY" {
+		t.Errorf("expecting %q, got %q", "DY", p)
+	}
+}
+
+func TestTailSection(t *testing.T) {
+	data := []byte("[header]")
+	w := Create(&data)
+	s := w.TailSection(8, -1)
+
+	if n, err := s.Write([]byte("[body]")); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if n != 6 {
+		t.Errorf("expecting to write 6 bytes, wrote %d", n)
+	} else if string(data) != "[header][body]" {
+		t.Errorf("expecting %q, got %q", "[header][body]", data)
+	} else if s.Size() != 6 {
+		t.Errorf("expecting size 6, got %d", s.Size())
+	}
+}
+
+func TestBoundedTailSection(t *testing.T) {
+	data := []byte("[header]")
+	w := Create(&data)
+	s := w.TailSection(8, 4)
+
+	if n, err := s.Write([]byte("[body]")); n != 4 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 4 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if string(data) != "[header][bod" {
+		t.Errorf("expecting %q, got %q", "[header][bod", data)
+	} else if n, err = s.WriteAt([]byte("B"), 1); n != 1 || err != nil {
+		t.Errorf("expecting to write 1 byte with nil error, wrote %d with %v", n, err)
+	} else if n, err = s.WriteAt([]byte("x"), 4); n != 0 || err != io.ErrShortWrite {
+		t.Errorf("expecting to write 0 bytes with io.ErrShortWrite, wrote %d with %v", n, err)
+	} else if w.WriteAt([]byte("[tail]"), 12); s.Size() != 4 {
+		t.Errorf("expecting size 4, got %d", s.Size())
+	} else if string(data) != "[header][Bod[tail]" {
+		t.Errorf("expecting %q, got %q", "[header][Bod[tail]", data)
+	}
+}
@@ -0,0 +1,258 @@
+package memio
+
+import (
+	"io"
+	"math"
+	"os"
+	"sync"
+)
+
+const seekableChunkSize = 32768
+
+// SeekableReader records the data read from a non-seekable io.Reader so that
+// it can be seeked and read at any offset.
+//
+// Data is only read from the underlying reader as far as is required to
+// satisfy a read, apart from seeking relative to the end, which requires that
+// all of the data be read.
+//
+// ReadAt may be called concurrently with other calls to ReadAt.
+type SeekableReader struct {
+	mu     sync.Mutex
+	r      io.Reader
+	err    error
+	data   []byte
+	buf    []byte
+	size   int64
+	pos    int64
+	spill  int64
+	dir    string
+	file   *os.File
+	closed bool
+}
+
+// Seekable creates a SeekableReader that records the data read from r in
+// memory.
+func Seekable(r io.Reader) *SeekableReader {
+	return &SeekableReader{r: r}
+}
+
+// SeekableSpill creates a SeekableReader that records the data read from r in
+// memory until it exceeds the given threshold, at which point the recorded
+// data is moved to a temporary file in the given directory. If dir is the
+// empty string, the default directory for temporary files is used.
+//
+// The temporary file is removed by Close.
+func SeekableSpill(r io.Reader, threshold int64, dir string) *SeekableReader {
+	return &SeekableReader{r: r, spill: threshold, dir: dir}
+}
+
+// Read is an implementation of the io.Reader interface.
+//
+// Unlike ReadAt, Read returns the data that is available after at most one
+// read from the underlying reader, once any data before the position has been
+// recorded.
+func (s *SeekableReader) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, opError("read", s.pos, ErrClosed)
+	} else if len(p) == 0 {
+		return 0, nil
+	}
+
+	err := s.fill(s.pos)
+	if err == nil && s.pos >= s.size && s.err == nil {
+		err = s.readChunk()
+	}
+
+	if s.pos >= s.size {
+		if err == nil {
+			err = s.err
+		}
+
+		return 0, err
+	}
+
+	n, err := s.readRecorded(p[:min(int64(len(p)), s.size-s.pos)], s.pos)
+	s.pos += int64(n)
+
+	return n, err
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (s *SeekableReader) ReadAt(p []byte, off int64) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return 0, opError("readat", off, ErrClosed)
+	} else if !validRange(off, int64(len(p))) {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	}
+
+	end := off + int64(len(p))
+	ferr := s.fill(end)
+
+	if off >= s.size {
+		if ferr != nil {
+			return 0, ferr
+		}
+
+		return 0, io.EOF
+	}
+
+	end = min(end, s.size)
+
+	n, err := s.readRecorded(p[:end-off], off)
+	if err == nil {
+		err = ferr
+	}
+
+	if err == nil && n < len(p) {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+// Seek is an implementation of the io.Seeker interface.
+//
+// Seeking relative to the end reads all remaining data from the underlying
+// reader.
+func (s *SeekableReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += s.pos
+	case seekEnd:
+		s.mu.Lock()
+		err := s.fill(math.MaxInt64)
+		size := s.size
+		s.mu.Unlock()
+
+		if err != nil {
+			return 0, err
+		}
+
+		offset += size
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	s.pos = offset
+
+	return offset, nil
+}
+
+// Close is an implementation of the io.Closer interface, and removes any
+// temporary file.
+func (s *SeekableReader) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+	s.data = nil
+	s.buf = nil
+
+	if s.file != nil {
+		name := s.file.Name()
+
+		s.file.Close()
+
+		return os.Remove(name)
+	}
+
+	return nil
+}
+
+// readRecorded reads recorded data, from memory or the temporary file.
+func (s *SeekableReader) readRecorded(p []byte, off int64) (int, error) {
+	if s.file != nil {
+		return s.file.ReadAt(p, off)
+	}
+
+	return copy(p, s.data[off:]), nil
+}
+
+// fill reads from the underlying reader until at least end bytes have been
+// recorded, or the reader is exhausted.
+func (s *SeekableReader) fill(end int64) error {
+	for s.size < end && s.err == nil {
+		if err := s.readChunk(); err != nil {
+			return err
+		}
+	}
+
+	if s.size < end && s.err != io.EOF {
+		return s.err
+	}
+
+	return nil
+}
+
+// readChunk records the data from a single read of the underlying reader.
+func (s *SeekableReader) readChunk() error {
+	if s.file != nil {
+		if s.buf == nil {
+			s.buf = make([]byte, seekableChunkSize)
+		}
+
+		n, err := s.r.Read(s.buf)
+		if _, werr := s.file.WriteAt(s.buf[:n], s.size); werr != nil {
+			return werr
+		}
+
+		s.size += int64(n)
+		s.err = err
+
+		return nil
+	}
+
+	if cap(s.data)-len(s.data) < minRead {
+		data := make([]byte, len(s.data), max(cap(s.data)<<1, seekableChunkSize))
+
+		copy(data, s.data)
+
+		s.data = data
+	}
+
+	n, err := s.r.Read(s.data[len(s.data):cap(s.data)])
+	s.data = s.data[:len(s.data)+n]
+	s.size += int64(n)
+	s.err = err
+
+	if s.spill > 0 && s.size > s.spill {
+		return s.spillToFile()
+	}
+
+	return nil
+}
+
+func (s *SeekableReader) spillToFile() error {
+	f, err := os.CreateTemp(s.dir, "memio-")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(s.data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+
+		return err
+	}
+
+	s.file = f
+	s.data = nil
+
+	return nil
+}
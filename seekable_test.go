@@ -0,0 +1,132 @@
+package memio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var (
+	_ io.ReadSeekCloser = new(SeekableReader)
+	_ io.ReaderAt       = new(SeekableReader)
+)
+
+type countingReader struct {
+	io.Reader
+	read int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.read += n
+
+	return n, err
+}
+
+func TestSeekable(t *testing.T) {
+	data := strings.Repeat("0123456789", 10000)
+
+	for n, test := range [...]struct {
+		New     func(io.Reader) *SeekableReader
+		Spilled bool
+	}{
+		{Seekable, false},
+		{func(r io.Reader) *SeekableReader { return SeekableSpill(r, 50000, t.TempDir()) }, true},
+	} {
+		c := &countingReader{Reader: struct{ io.Reader }{strings.NewReader(data)}}
+		s := test.New(c)
+		buf := make([]byte, 10)
+
+		if m, err := s.Read(buf); err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		} else if string(buf[:m]) != "0123456789" {
+			t.Fatalf("test %d: expecting %q, got %q", n+1, "0123456789", buf[:m])
+		} else if c.read >= len(data) {
+			t.Fatalf("test %d: expecting source to be read lazily", n+1)
+		} else if m, err = s.ReadAt(buf[:5], 60003); err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		} else if string(buf[:m]) != "34567" {
+			t.Fatalf("test %d: expecting %q, got %q", n+1, "34567", buf[:m])
+		} else if (s.file != nil) != test.Spilled {
+			t.Fatalf("test %d: expecting spilled to be %v", n+1, test.Spilled)
+		} else if pos, err := s.Seek(-5, seekEnd); err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		} else if pos != int64(len(data)-5) {
+			t.Fatalf("test %d: expecting position %d, got %d", n+1, len(data)-5, pos)
+		} else if m, err = s.Read(buf); err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		} else if string(buf[:m]) != "56789" {
+			t.Fatalf("test %d: expecting %q, got %q", n+1, "56789", buf[:m])
+		} else if _, err = s.Read(buf); err != io.EOF {
+			t.Fatalf("test %d: expecting io.EOF, got %v", n+1, err)
+		} else if _, err = s.Seek(0, seekSet); err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		}
+
+		var out bytes.Buffer
+
+		if _, err := io.Copy(&out, s); err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		} else if out.String() != data {
+			t.Fatalf("test %d: re-read data does not match", n+1)
+		} else if err = s.Close(); err != nil {
+			t.Fatalf("test %d: unexpected error: %s", n+1, err)
+		}
+	}
+}
+
+func TestSeekableError(t *testing.T) {
+	errFailed := errors.New("failed")
+	s := Seekable(io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(errFailed)))
+	buf := make([]byte, 5)
+
+	if n, err := s.ReadAt(buf, 1); n != 2 || err != errFailed {
+		t.Errorf("expecting to read 2 bytes with errFailed, read %d with %v", n, err)
+	} else if string(buf[:n]) != "bc" {
+		t.Errorf("expecting %q, got %q", "bc", buf[:n])
+	} else if n, err = s.ReadAt(buf, 3); n != 0 || err != errFailed {
+		t.Errorf("expecting to read 0 bytes with errFailed, read %d with %v", n, err)
+	} else if n, err = s.ReadAt(buf[:2], 0); n != 2 || err != nil {
+		t.Errorf("expecting to read 2 bytes with nil error, read %d with %v", n, err)
+	} else if _, err = s.ReadAt(buf, math.MaxInt64-2); !errors.Is(err, ErrInvalidOffset) {
+		t.Errorf("expecting ErrInvalidOffset, got %v", err)
+	}
+}
+
+func TestSeekableStream(t *testing.T) {
+	pr, pw := io.Pipe()
+	s := SeekableSpill(pr, 2, t.TempDir())
+	buf := make([]byte, 10)
+
+	defer s.Close()
+
+	for n, chunk := range [...]string{"abc", "def", "ghi"} {
+		go pw.Write([]byte(chunk))
+
+		if m, err := s.Read(buf); m != 3 || err != nil {
+			t.Fatalf("test %d: expecting to read 3 bytes with nil error, read %d with %v", n+1, m, err)
+		} else if string(buf[:m]) != chunk {
+			t.Fatalf("test %d: expecting %q, got %q", n+1, chunk, buf[:m])
+		}
+	}
+
+	spill := s.buf
+
+	pw.Close()
+
+	if s.file == nil {
+		t.Errorf("expecting data to have been spilled")
+	} else if n, err := s.Read(buf); n != 0 || err != io.EOF {
+		t.Errorf("expecting to read 0 bytes with io.EOF, read %d with %v", n, err)
+	} else if &s.buf[0] != &spill[0] {
+		t.Errorf("expecting spill buffer to be reused")
+	} else if n, err = s.ReadAt(buf, 2); n != 7 || err != io.EOF {
+		t.Errorf("expecting to read 7 bytes with io.EOF, read %d with %v", n, err)
+	} else if string(buf[:n]) != "cdefghi" {
+		t.Errorf("expecting %q, got %q", "cdefghi", buf[:n])
+	}
+}
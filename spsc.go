@@ -0,0 +1,260 @@
+package memio
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+const cacheLine = 64
+
+// SPSCRing is a lock-free ring buffer for use by a single producer goroutine
+// and a single consumer goroutine.
+//
+// In addition to the blocking Read and Write methods, it offers a zero-copy
+// API, with ReserveWrite and CommitWrite for the producer, and PeekRead and
+// CommitRead for the consumer. These methods never block or allocate; the
+// WaitWrite and WaitRead methods can be used to sleep until they can make
+// progress.
+type SPSCRing struct {
+	buf  []byte
+	mask uint64
+
+	_    [cacheLine]byte
+	head atomic.Uint64
+	_    [cacheLine - 8]byte
+	tail atomic.Uint64
+	_    [cacheLine - 8]byte
+
+	readWaiting, writeWaiting atomic.Bool
+	closed                    atomic.Bool
+	readWake, writeWake       chan struct{}
+}
+
+// NewSPSCRing creates a SPSCRing with a buffer of at least the given size,
+// rounded up to a power of two.
+func NewSPSCRing(size int) *SPSCRing {
+	s := 1
+
+	for s < size {
+		s <<= 1
+	}
+
+	return &SPSCRing{
+		buf:       make([]byte, s),
+		mask:      uint64(s - 1),
+		readWake:  make(chan struct{}, 1),
+		writeWake: make(chan struct{}, 1),
+	}
+}
+
+// Len returns the number of bytes available to be read.
+func (s *SPSCRing) Len() int {
+	return int(s.tail.Load() - s.head.Load())
+}
+
+// Cap returns the size of the ring buffer.
+func (s *SPSCRing) Cap() int {
+	return len(s.buf)
+}
+
+// ReserveWrite returns a slice of up to n bytes of free space that the
+// producer can write to. The returned slice may be shorter than n if there is
+// insufficient space, or the free space wraps around the end of the buffer.
+//
+// The written bytes are made available to the consumer with CommitWrite.
+func (s *SPSCRing) ReserveWrite(n int) []byte {
+	tail := s.tail.Load()
+	start := int(tail & s.mask)
+	free := len(s.buf) - int(tail-s.head.Load())
+
+	if n > free {
+		n = free
+	} else if n < 0 {
+		n = 0
+	}
+
+	if n > len(s.buf)-start {
+		n = len(s.buf) - start
+	}
+
+	return s.buf[start : start+n]
+}
+
+// CommitWrite makes n bytes, written to the slice returned by ReserveWrite,
+// available to the consumer.
+func (s *SPSCRing) CommitWrite(n int) {
+	if free := len(s.buf) - s.Len(); n > free {
+		n = free
+	} else if n < 0 {
+		n = 0
+	}
+
+	s.tail.Add(uint64(n))
+
+	if s.readWaiting.Load() {
+		wake(s.readWake)
+	}
+}
+
+// PeekRead returns a slice of the bytes available to be read. The slice may not
+// contain all available bytes if they wrap around the end of the buffer.
+//
+// The bytes are released back to the producer with CommitRead.
+func (s *SPSCRing) PeekRead() []byte {
+	head := s.head.Load()
+	start := int(head & s.mask)
+	n := int(s.tail.Load() - head)
+
+	if n > len(s.buf)-start {
+		n = len(s.buf) - start
+	}
+
+	return s.buf[start : start+n]
+}
+
+// CommitRead releases n bytes, returned by PeekRead, back to the producer.
+func (s *SPSCRing) CommitRead(n int) {
+	if l := s.Len(); n > l {
+		n = l
+	} else if n < 0 {
+		n = 0
+	}
+
+	s.head.Add(uint64(n))
+
+	if s.writeWaiting.Load() {
+		wake(s.writeWake)
+	}
+}
+
+// WaitRead blocks until at least n bytes are available to be read.
+//
+// Returns ErrClosed if the ring is closed before that many bytes are available.
+func (s *SPSCRing) WaitRead(n int) error {
+	if n > len(s.buf) {
+		return ErrRingSize
+	}
+
+	return s.wait(&s.readWaiting, s.readWake, func() bool {
+		return s.Len() >= n
+	})
+}
+
+// WaitWrite blocks until at least n bytes of free space are available to be
+// written.
+//
+// Returns ErrClosed if the ring is closed.
+func (s *SPSCRing) WaitWrite(n int) error {
+	if n > len(s.buf) {
+		return ErrRingSize
+	}
+
+	if err := s.wait(&s.writeWaiting, s.writeWake, func() bool {
+		return s.closed.Load() || len(s.buf)-s.Len() >= n
+	}); err != nil {
+		return err
+	} else if s.closed.Load() {
+		return ErrClosed
+	}
+
+	return nil
+}
+
+func (s *SPSCRing) wait(waiting *atomic.Bool, ch chan struct{}, ready func() bool) error {
+	for !ready() {
+		waiting.Store(true)
+
+		if ready() {
+			waiting.Store(false)
+
+			break
+		} else if s.closed.Load() {
+			waiting.Store(false)
+
+			return ErrClosed
+		}
+
+		<-ch
+		waiting.Store(false)
+	}
+
+	return nil
+}
+
+// Read is an implementation of the io.Reader interface.
+//
+// Read blocks until at least one byte is available, returning io.EOF once the
+// ring is closed and empty.
+func (s *SPSCRing) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if err := s.WaitRead(1); err != nil {
+		if err == ErrClosed {
+			return 0, io.EOF
+		}
+
+		return 0, err
+	}
+
+	var n int
+
+	for n < len(p) {
+		buf := s.PeekRead()
+		if len(buf) == 0 {
+			break
+		}
+
+		m := copy(p[n:], buf)
+		n += m
+
+		s.CommitRead(m)
+	}
+
+	return n, nil
+}
+
+// Write is an implementation of the io.Writer interface.
+//
+// Write blocks until all of the bytes are written, or the ring is closed.
+func (s *SPSCRing) Write(p []byte) (int, error) {
+	var n int
+
+	for n < len(p) {
+		if err := s.WaitWrite(1); err != nil {
+			return n, err
+		}
+
+		m := copy(s.ReserveWrite(len(p)-n), p[n:])
+		n += m
+
+		s.CommitWrite(m)
+	}
+
+	return n, nil
+}
+
+// Close closes the ring, waking any waiting goroutines. The consumer can still
+// read any remaining bytes.
+func (s *SPSCRing) Close() error {
+	s.closed.Store(true)
+
+	wake(s.readWake)
+	wake(s.writeWake)
+
+	return nil
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// Errors.
+var (
+	ErrRingSize = errors.New("size exceeds ring capacity")
+)
@@ -0,0 +1,101 @@
+package memio
+
+import (
+	"io"
+	"testing"
+)
+
+var _ io.ReadWriteCloser = new(SPSCRing)
+
+func TestSPSCRing(t *testing.T) {
+	const total = 1 << 20
+
+	s := NewSPSCRing(1000)
+
+	if s.Cap() != 1024 {
+		t.Fatalf("expecting capacity 1024, got %d", s.Cap())
+	}
+
+	go func() {
+		buf := make([]byte, 300)
+
+		for n := 0; n < total; {
+			m := min(len(buf), total-n, n%277+1)
+
+			for i := range buf[:m] {
+				buf[i] = byte(n + i)
+			}
+
+			s.Write(buf[:m])
+
+			n += m
+		}
+
+		s.Close()
+	}()
+
+	var n int
+
+	for {
+		if err := s.WaitRead(1); err == ErrClosed {
+			break
+		} else if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		buf := s.PeekRead()
+
+		for i, c := range buf {
+			if c != byte(n+i) {
+				t.Fatalf("at offset %d: expecting byte %d, got %d", n+i, byte(n+i), c)
+			}
+		}
+
+		n += len(buf)
+
+		s.CommitRead(len(buf))
+	}
+
+	if n != total {
+		t.Errorf("expecting to read %d bytes, read %d", total, n)
+	}
+}
+
+func TestSPSCRingReserve(t *testing.T) {
+	s := NewSPSCRing(8)
+
+	buf := s.ReserveWrite(6)
+	if len(buf) != 6 {
+		t.Fatalf("expecting 6 bytes, got %d", len(buf))
+	}
+
+	copy(buf, "abcdef")
+	s.CommitWrite(6)
+
+	if s.Len() != 6 {
+		t.Errorf("expecting length 6, got %d", s.Len())
+	} else if buf = s.PeekRead(); string(buf) != "abcdef" {
+		t.Errorf("expecting %q, got %q", "abcdef", buf)
+	}
+
+	s.CommitRead(4)
+
+	if buf := s.ReserveWrite(6); len(buf) != 2 {
+		t.Errorf("expecting 2 bytes, got %d", len(buf))
+	}
+
+	s.CommitWrite(2)
+
+	if buf := s.ReserveWrite(6); len(buf) != 4 {
+		t.Errorf("expecting 4 bytes, got %d", len(buf))
+	} else if err := s.WaitRead(9); err != ErrRingSize {
+		t.Errorf("expecting ErrRingSize, got %v", err)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		s.CommitWrite(len(s.ReserveWrite(4)))
+		s.CommitRead(len(s.PeekRead()))
+	}); allocs != 0 {
+		t.Errorf("expecting no allocations, got %f", allocs)
+	}
+}
@@ -27,7 +27,7 @@ func (s *String) Read(p []byte) (int, error) {
 // WriteTo satisfies the io.WriterTo interface.
 func (s *String) WriteTo(w io.Writer) (int64, error) {
 	if len(*s) == 0 {
-		return 0, io.EOF
+		return 0, nil
 	}
 
 	n, err := io.WriteString(w, string(*s))
@@ -62,13 +62,27 @@ func (s *String) ReadRune() (rune, int, error) {
 
 // Peek reads the next n bytes without advancing the position.
 func (s *String) Peek(n int) ([]byte, error) {
-	if n > len(*s) {
+	if n < 0 {
+		return nil, opError("peek", 0, ErrInvalidCount)
+	} else if n > len(*s) {
 		return []byte(*s), io.EOF
 	}
 
 	return []byte((*s)[:n]), nil
 }
 
+// PeekString returns the next n bytes, as a substring, without advancing the
+// position.
+func (s *String) PeekString(n int) (string, error) {
+	if n < 0 {
+		return "", opError("peek", 0, ErrInvalidCount)
+	} else if n > len(*s) {
+		return string(*s), io.EOF
+	}
+
+	return string((*s)[:n]), nil
+}
+
 // Close satisfies the io.Closer interface.
 func (s *String) Close() error {
 	*s = ""
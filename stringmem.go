@@ -0,0 +1,196 @@
+package memio
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// StringMem holds a string that can be used for many io interfaces, without
+// copying it to a byte slice.
+type StringMem struct {
+	data     string
+	pos      int64
+	lastRune int
+}
+
+// OpenString uses a string for reading. Implements io.Reader, io.Seeker,
+// io.Closer, io.ReaderAt, io.ByteScanner, io.RuneScanner and io.WriterTo.
+func OpenString(data string) *StringMem {
+	return &StringMem{data: data}
+}
+
+// Reset resets the StringMem to read from the given string.
+func (s *StringMem) Reset(data string) {
+	*s = StringMem{data: data}
+}
+
+// Close is a no-op func the lets StringMem implement interfaces that require a
+// Close method.
+func (*StringMem) Close() error {
+	return nil
+}
+
+// Len returns the number of unread bytes.
+func (s *StringMem) Len() int {
+	if s.pos >= int64(len(s.data)) {
+		return 0
+	}
+
+	return len(s.data) - int(s.pos)
+}
+
+// Size returns the length of the underlying string.
+func (s *StringMem) Size() int64 {
+	return int64(len(s.data))
+}
+
+// Read is an implementation of the io.Reader interface.
+func (s *StringMem) Read(p []byte) (int, error) {
+	s.lastRune = 0
+
+	if s.pos >= int64(len(s.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, s.data[s.pos:])
+	s.pos += int64(n)
+
+	return n, nil
+}
+
+// ReadAt is an implementation of the io.ReaderAt interface.
+func (s *StringMem) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, opError("readat", off, ErrInvalidOffset)
+	} else if off >= int64(len(s.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, s.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+// ReadByte is an implementation of the io.ByteReader interface.
+func (s *StringMem) ReadByte() (byte, error) {
+	s.lastRune = 0
+
+	if s.pos >= int64(len(s.data)) {
+		return 0, io.EOF
+	}
+
+	c := s.data[s.pos]
+	s.pos++
+
+	return c, nil
+}
+
+// UnreadByte is an implementation of the io.ByteScanner interface.
+func (s *StringMem) UnreadByte() error {
+	if s.pos <= 0 {
+		return ErrInvalidUnreadByte
+	}
+
+	s.lastRune = 0
+	s.pos--
+
+	return nil
+}
+
+// ReadRune is an implementation of the io.RuneReader interface.
+func (s *StringMem) ReadRune() (rune, int, error) {
+	s.lastRune = 0
+
+	if s.pos >= int64(len(s.data)) {
+		return 0, 0, io.EOF
+	}
+
+	r, n := utf8.DecodeRuneInString(s.data[s.pos:])
+	s.pos += int64(n)
+	s.lastRune = n
+
+	return r, n, nil
+}
+
+// UnreadRune is an implementation of the io.RuneScanner interface.
+func (s *StringMem) UnreadRune() error {
+	if s.lastRune == 0 {
+		return ErrInvalidUnreadRune
+	}
+
+	s.pos -= int64(s.lastRune)
+	s.lastRune = 0
+
+	return nil
+}
+
+// Seek is an implementation of the io.Seeker interface.
+func (s *StringMem) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case seekSet:
+	case seekCurr:
+		offset += s.pos
+	case seekEnd:
+		offset += int64(len(s.data))
+	default:
+		return 0, opError("seek", offset, ErrInvalidWhence)
+	}
+
+	if offset < 0 {
+		return 0, opError("seek", offset, ErrInvalidOffset)
+	}
+
+	s.lastRune = 0
+	s.pos = offset
+
+	return offset, nil
+}
+
+// WriteTo is an implementation of the io.WriterTo interface.
+func (s *StringMem) WriteTo(w io.Writer) (int64, error) {
+	s.lastRune = 0
+
+	if s.pos >= int64(len(s.data)) {
+		return 0, nil
+	}
+
+	data := s.data[s.pos:]
+	n, err := io.WriteString(w, data)
+	s.pos += int64(n)
+
+	if n != len(data) && err == nil {
+		err = io.ErrShortWrite
+	}
+
+	return int64(n), err
+}
+
+// Peek reads the next n bytes without advancing the position.
+//
+// As the returned bytes are a copy of the string data, PeekString should be
+// preferred.
+func (s *StringMem) Peek(n int) ([]byte, error) {
+	str, err := s.PeekString(n)
+
+	return []byte(str), err
+}
+
+// PeekString returns the next n bytes, as a substring of the underlying
+// string, without advancing the position.
+func (s *StringMem) PeekString(n int) (string, error) {
+	if n < 0 {
+		return "", opError("peek", s.pos, ErrInvalidCount)
+	} else if s.pos >= int64(len(s.data)) {
+		return "", io.EOF
+	}
+
+	data := s.data[s.pos:]
+	if n > len(data) {
+		return data, io.EOF
+	}
+
+	return data[:n], nil
+}
@@ -0,0 +1,83 @@
+package memio
+
+import (
+	"io"
+	"testing"
+)
+
+var (
+	_ io.ReadSeekCloser = &StringMem{}
+	_ io.ReaderAt       = &StringMem{}
+	_ io.RuneScanner    = &StringMem{}
+	_ io.WriterTo       = &StringMem{}
+	_ Marker            = &StringMem{}
+)
+
+func TestStringMem(t *testing.T) {
+	s := OpenString("Hello, 世界")
+	buf := make([]byte, 5)
+
+	if n, err := s.Read(buf); n != 5 || err != nil {
+		t.Errorf("expecting 5, nil, got %d, %v", n, err)
+	} else if str, err := s.PeekString(5); str != ", 世" || err != nil {
+		t.Errorf("expecting %q, nil, got %q, %v", ", 世", str, err)
+	} else if n, err = s.ReadAt(buf[:3], 7); n != 3 || err != nil || string(buf[:3]) != "世" {
+		t.Errorf("expecting 3, nil, %q, got %d, %v, %q", "世", n, err, buf[:3])
+	} else if pos, err := s.Seek(-3, io.SeekEnd); pos != 10 || err != nil {
+		t.Errorf("expecting 10, nil, got %d, %v", pos, err)
+	} else if r, _, err := s.ReadRune(); r != '界' || err != nil {
+		t.Errorf("expecting %q, nil, got %q, %v", '界', r, err)
+	} else if err = s.UnreadRune(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if s.Len() != 3 || s.Size() != 13 {
+		t.Errorf("expecting Len 3 and Size 13, got %d and %d", s.Len(), s.Size())
+	} else if str, err = s.PeekString(10); str != "界" || err != io.EOF {
+		t.Errorf("expecting %q, io.EOF, got %q, %v", "界", str, err)
+	} else if _, err = s.Seek(20, io.SeekStart); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if str, err = s.PeekString(1); str != "" || err != io.EOF {
+		t.Errorf("expecting %q, io.EOF, got %q, %v", "", str, err)
+	} else if _, err = s.Seek(-1, io.SeekStart); err == nil {
+		t.Errorf("expecting error seeking to negative position")
+	} else if _, err = s.Seek(-3, io.SeekEnd); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if r, _, err = s.ReadRune(); r != '界' || err != nil {
+		t.Errorf("expecting %q, nil, got %q, %v", '界', r, err)
+	} else if str, err = s.PeekString(1); str != "" || err != io.EOF {
+		t.Errorf("expecting %q, io.EOF, got %q, %v", "", str, err)
+	} else if err = s.UnreadRune(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if err = s.UnreadRune(); err != ErrInvalidUnreadRune {
+		t.Errorf("expecting ErrInvalidUnreadRune, got %v", err)
+	} else if pos, err = s.Seek(0, io.SeekCurrent); pos != 10 || err != nil {
+		t.Errorf("expecting 10, nil, got %d, %v", pos, err)
+	} else if allocs := testing.AllocsPerRun(10, func() { s.Seek(0, io.SeekStart); s.PeekString(5) }); allocs != 0 {
+		t.Errorf("expecting no allocations, got %f", allocs)
+	}
+}
+
+func TestStringMemReset(t *testing.T) {
+	s := OpenString("Hello")
+
+	s.Reset("abc")
+
+	if str, err := s.PeekString(5); str != "abc" || err != io.EOF {
+		t.Errorf("expecting %q, io.EOF, got %q, %v", "abc", str, err)
+	} else if pos, err := s.Seek(-1, io.SeekEnd); pos != 2 || err != nil {
+		t.Errorf("expecting 2, nil, got %d, %v", pos, err)
+	} else if str, err = s.PeekString(1); str != "c" || err != nil {
+		t.Errorf("expecting %q, nil, got %q, %v", "c", str, err)
+	}
+}
+
+func TestStringPeekString(t *testing.T) {
+	s := String("Hello")
+
+	if str, err := s.PeekString(2); str != "He" || err != nil {
+		t.Errorf("expecting %q, nil, got %q, %v", "He", str, err)
+	} else if str, err = s.PeekString(6); str != "Hello" || err != io.EOF {
+		t.Errorf("expecting %q, io.EOF, got %q, %v", "Hello", str, err)
+	} else if allocs := testing.AllocsPerRun(10, func() { s.PeekString(3) }); allocs != 0 {
+		t.Errorf("expecting no allocations, got %f", allocs)
+	}
+}
@@ -0,0 +1,565 @@
+package memio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is a text encoding that can be transcoded to and from UTF-8.
+type Encoding uint8
+
+// Supported encodings.
+const (
+	EncodingUTF8 Encoding = iota
+	EncodingUTF16LE
+	EncodingUTF16BE
+	EncodingUTF32LE
+	EncodingUTF32BE
+	EncodingLatin1
+	EncodingWindows1252
+)
+
+const maxRuneSize = 4
+
+var boms = [...]string{
+	EncodingUTF8:    "\xef\xbb\xbf",
+	EncodingUTF16LE: "\xff\xfe",
+	EncodingUTF16BE: "\xfe\xff",
+	EncodingUTF32LE: "\xff\xfe\x00\x00",
+	EncodingUTF32BE: "\x00\x00\xfe\xff",
+}
+
+var windows1252 = [32]rune{
+	0x20AC, utf8.RuneError, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, utf8.RuneError, 0x017D, utf8.RuneError,
+	utf8.RuneError, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, utf8.RuneError, 0x017E, 0x0178,
+}
+
+// Decoder reads text in a given encoding from an io.Reader, presenting it as
+// UTF-8.
+//
+// When decoding one of the Unicode encodings, a byte-order mark at the start
+// of the text is removed, and selects the encoding it indicates.
+//
+// Invalid sequences are decoded as utf8.RuneError, unless the Decoder is
+// strict, in which case an *OpError is returned with the offset of the
+// sequence in the original bytes.
+type Decoder struct {
+	r        io.Reader
+	raw      Buffer
+	err      error
+	enc      Encoding
+	strict   bool
+	bom      bool
+	off      int64
+	carry    Buffer
+	peek     []byte
+	mark     Mark
+	lastSize int
+}
+
+// NewDecoder creates a Decoder that decodes text, in the given encoding, from
+// the given reader.
+func NewDecoder(r io.Reader, enc Encoding) *Decoder {
+	return &Decoder{r: r, enc: enc, lastSize: -1}
+}
+
+// NewStrictDecoder creates a Decoder that returns an error when it encounters
+// an invalid sequence.
+func NewStrictDecoder(r io.Reader, enc Encoding) *Decoder {
+	return &Decoder{r: r, enc: enc, strict: true, lastSize: -1}
+}
+
+// Encoding returns the encoding being decoded, which may have been changed by
+// a byte-order mark.
+func (d *Decoder) Encoding() Encoding {
+	d.detectBOM()
+
+	return d.enc
+}
+
+// Offset returns the offset, in the original bytes, of the next rune to be
+// read.
+func (d *Decoder) Offset() int64 {
+	return d.off
+}
+
+// ReadRune is an implementation of the io.RuneReader interface.
+//
+// The returned size is the number of bytes the rune occupied in the original
+// encoding.
+//
+// If a previous call to Read split a rune, the remaining bytes of that rune are
+// returned as utf8.RuneError, with a size of zero.
+func (d *Decoder) ReadRune() (rune, int, error) {
+	d.lastSize = -1
+
+	if len(d.carry) > 0 {
+		d.carry = d.carry[1:]
+
+		return utf8.RuneError, 0, nil
+	}
+
+	d.detectBOM()
+
+	r, size, valid := d.decode(true)
+	if size == 0 {
+		return 0, 0, d.readErr()
+	} else if !valid {
+		if d.strict {
+			return 0, 0, opError("decode", d.off, ErrInvalidSequence)
+		}
+
+		r = utf8.RuneError
+	}
+
+	d.mark = d.raw.Mark()
+	d.raw = d.raw[size:]
+	d.off += int64(size)
+	d.lastSize = size
+
+	return r, size, nil
+}
+
+// UnreadRune is an implementation of the io.RuneScanner interface.
+func (d *Decoder) UnreadRune() error {
+	if d.lastSize < 0 {
+		return ErrInvalidUnreadRune
+	}
+
+	d.off -= int64(d.lastSize)
+	d.lastSize = -1
+
+	return d.raw.ResetTo(d.mark)
+}
+
+// Read is an implementation of the io.Reader interface, reading the decoded
+// text as UTF-8.
+func (d *Decoder) Read(p []byte) (int, error) {
+	d.lastSize = -1
+
+	n := copy(p, d.carry)
+	d.carry = d.carry[n:]
+
+	d.detectBOM()
+
+	for n < len(p) {
+		r, size, valid := d.decode(n == 0)
+		if size == 0 {
+			if n == 0 {
+				return 0, d.readErr()
+			}
+
+			break
+		} else if !valid {
+			if !d.strict {
+				r = utf8.RuneError
+			} else if n == 0 {
+				return 0, opError("decode", d.off, ErrInvalidSequence)
+			} else {
+				break
+			}
+		}
+
+		if utf8.RuneLen(r) > len(p)-n {
+			if n > 0 {
+				break
+			}
+
+			d.carry = utf8.AppendRune(d.carry[:0], r)
+			n = copy(p, d.carry)
+			d.carry = d.carry[n:]
+		} else {
+			n += utf8.EncodeRune(p[n:], r)
+		}
+
+		d.raw = d.raw[size:]
+		d.off += int64(size)
+	}
+
+	return n, nil
+}
+
+// Peek returns the next n bytes of the decoded text, as UTF-8, without
+// advancing the position.
+//
+// The returned slice is only valid until the next call to a method of the
+// Decoder.
+func (d *Decoder) Peek(n int) ([]byte, error) {
+	d.lastSize = -1
+
+	if n < 0 {
+		return nil, opError("peek", d.off, ErrInvalidCount)
+	}
+
+	d.detectBOM()
+
+	d.peek = append(d.peek[:0], d.carry...)
+	pos := 0
+
+	for len(d.peek) < n {
+		r, size, valid := decodeRune(d.enc, d.raw[pos:], d.err != nil)
+		if size == 0 {
+			if d.err != nil {
+				return d.peek, d.readErr()
+			}
+
+			d.fill(len(d.raw) + 1)
+
+			continue
+		} else if !valid {
+			if d.strict {
+				return d.peek, opError("decode", d.off+int64(pos), ErrInvalidSequence)
+			}
+
+			r = utf8.RuneError
+		}
+
+		d.peek = utf8.AppendRune(d.peek, r)
+		pos += size
+	}
+
+	return d.peek[:n], nil
+}
+
+// decode decodes the next rune, reading from the underlying reader when more
+// data is required and wait is true.
+func (d *Decoder) decode(wait bool) (rune, int, bool) {
+	for {
+		r, size, valid := decodeRune(d.enc, d.raw, d.err != nil)
+		if size > 0 || d.err != nil || !wait {
+			return r, size, valid
+		}
+
+		d.fill(len(d.raw) + 1)
+	}
+}
+
+func (d *Decoder) detectBOM() {
+	if d.bom {
+		return
+	}
+
+	d.bom = true
+
+	if d.enc > EncodingUTF32BE {
+		return
+	}
+
+	d.fill(maxRuneSize)
+
+	if !bytes.HasPrefix(d.raw, []byte(boms[d.enc])) {
+		for _, enc := range [...]Encoding{EncodingUTF32LE, EncodingUTF32BE, EncodingUTF8, EncodingUTF16LE, EncodingUTF16BE} {
+			if bytes.HasPrefix(d.raw, []byte(boms[enc])) {
+				d.enc = enc
+
+				break
+			}
+		}
+	}
+
+	if bom := boms[d.enc]; bytes.HasPrefix(d.raw, []byte(bom)) {
+		d.raw = d.raw[len(bom):]
+		d.off += int64(len(bom))
+	}
+}
+
+func (d *Decoder) fill(n int) {
+	for len(d.raw) < n && d.err == nil {
+		d.raw.reserve(max(n-len(d.raw), minRead))
+
+		m, err := d.r.Read(d.raw[len(d.raw):cap(d.raw)])
+		d.raw = d.raw[:len(d.raw)+m]
+		d.err = err
+	}
+}
+
+func (d *Decoder) readErr() error {
+	if d.err == nil {
+		return io.EOF
+	}
+
+	return d.err
+}
+
+// decodeRune decodes the first rune in p, returning the rune, the number of
+// bytes it occupies, and whether it was valid.
+//
+// A size of zero is returned when more bytes are required, or when p is empty
+// at EOF.
+func decodeRune(enc Encoding, p []byte, atEOF bool) (rune, int, bool) {
+	switch enc {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		if len(p) < 2 {
+			return shortRune(p, atEOF)
+		}
+
+		u := rune(uint16At(enc, p))
+		if !utf16.IsSurrogate(u) {
+			return u, 2, true
+		} else if u >= 0xDC00 {
+			return utf8.RuneError, 2, false
+		} else if len(p) < 4 {
+			if !atEOF {
+				return 0, 0, false
+			}
+
+			return utf8.RuneError, 2, false
+		}
+
+		if r := utf16.DecodeRune(u, rune(uint16At(enc, p[2:]))); r != utf8.RuneError {
+			return r, 4, true
+		}
+
+		return utf8.RuneError, 2, false
+	case EncodingUTF32LE, EncodingUTF32BE:
+		if len(p) < 4 {
+			return shortRune(p, atEOF)
+		}
+
+		var r rune
+
+		if enc == EncodingUTF32LE {
+			r = rune(binary.LittleEndian.Uint32(p))
+		} else {
+			r = rune(binary.BigEndian.Uint32(p))
+		}
+
+		if !utf8.ValidRune(r) {
+			return utf8.RuneError, 4, false
+		}
+
+		return r, 4, true
+	case EncodingLatin1:
+		if len(p) == 0 {
+			return 0, 0, false
+		}
+
+		return rune(p[0]), 1, true
+	case EncodingWindows1252:
+		if len(p) == 0 {
+			return 0, 0, false
+		} else if p[0] < 0x80 || p[0] >= 0xA0 {
+			return rune(p[0]), 1, true
+		}
+
+		r := windows1252[p[0]-0x80]
+
+		return r, 1, r != utf8.RuneError
+	}
+
+	if len(p) == 0 || !atEOF && !utf8.FullRune(p) {
+		return 0, 0, false
+	}
+
+	r, size := utf8.DecodeRune(p)
+
+	return r, size, r != utf8.RuneError || size > 1
+}
+
+func shortRune(p []byte, atEOF bool) (rune, int, bool) {
+	if !atEOF || len(p) == 0 {
+		return 0, 0, false
+	}
+
+	return utf8.RuneError, len(p), false
+}
+
+func uint16At(enc Encoding, p []byte) uint16 {
+	if enc == EncodingUTF16LE {
+		return binary.LittleEndian.Uint16(p)
+	}
+
+	return binary.BigEndian.Uint16(p)
+}
+
+// Encoder writes UTF-8 text to an io.Writer in a given encoding.
+//
+// Runes that cannot be represented in the encoding are written as '?', and
+// invalid UTF-8 as utf8.RuneError, unless the Encoder is strict, in which
+// case an *OpError is returned with the offset of the rune in the UTF-8 input.
+type Encoder struct {
+	w      io.Writer
+	enc    Encoding
+	strict bool
+	off    int64
+	carry  []byte
+	buf    []byte
+}
+
+// NewEncoder creates an Encoder that encodes text, in the given encoding, to
+// the given writer.
+func NewEncoder(w io.Writer, enc Encoding) *Encoder {
+	return &Encoder{w: w, enc: enc}
+}
+
+// NewStrictEncoder creates an Encoder that returns an error when it
+// encounters a rune that it cannot encode.
+func NewStrictEncoder(w io.Writer, enc Encoding) *Encoder {
+	return &Encoder{w: w, enc: enc, strict: true}
+}
+
+// WriteBOM writes the byte-order mark for the encoding, if it has one.
+func (e *Encoder) WriteBOM() error {
+	if e.enc > EncodingUTF32BE {
+		return nil
+	}
+
+	_, err := io.WriteString(e.w, boms[e.enc])
+
+	return err
+}
+
+// Write is an implementation of the io.Writer interface.
+//
+// An incomplete UTF-8 sequence at the end of p is kept until the next call to
+// Write or Flush.
+func (e *Encoder) Write(p []byte) (int, error) {
+	data := p
+	carried := len(e.carry)
+
+	if carried > 0 {
+		data = append(e.carry, p...)
+		e.carry = nil
+	}
+
+	var (
+		pos int
+		err error
+	)
+
+	e.buf = e.buf[:0]
+
+	for pos < len(data) {
+		if !utf8.FullRune(data[pos:]) {
+			e.carry = append(e.carry, data[pos:]...)
+			pos = len(data)
+
+			break
+		}
+
+		r, size := utf8.DecodeRune(data[pos:])
+		if err = e.encode(r, r != utf8.RuneError || size > 1); err != nil {
+			break
+		}
+
+		pos += size
+		e.off += int64(size)
+	}
+
+	if _, werr := e.w.Write(e.buf); werr != nil {
+		return 0, werr
+	}
+
+	return max(pos-carried, 0), err
+}
+
+// WriteString writes a string to the underlying writer in the encoding.
+func (e *Encoder) WriteString(s string) (int, error) {
+	return e.Write([]byte(s))
+}
+
+// WriteRune writes a rune to the underlying writer in the encoding, returning
+// the length of the rune in UTF-8.
+func (e *Encoder) WriteRune(r rune) (int, error) {
+	var b [utf8.UTFMax]byte
+
+	return e.Write(utf8.AppendRune(b[:0], r))
+}
+
+// Flush writes any incomplete UTF-8 sequence kept from a previous Write as an
+// invalid rune.
+func (e *Encoder) Flush() error {
+	if len(e.carry) == 0 {
+		return nil
+	}
+
+	e.buf = e.buf[:0]
+	err := e.encode(utf8.RuneError, false)
+	e.off += int64(len(e.carry))
+	e.carry = e.carry[:0]
+
+	if err != nil {
+		return err
+	}
+
+	_, err = e.w.Write(e.buf)
+
+	return err
+}
+
+func (e *Encoder) encode(r rune, valid bool) error {
+	if !valid {
+		if e.strict {
+			return opError("encode", e.off, ErrInvalidSequence)
+		}
+
+		r = utf8.RuneError
+	}
+
+	buf, ok := appendRune(e.buf, e.enc, r)
+	if !ok {
+		if e.strict {
+			return opError("encode", e.off, ErrUnencodable)
+		}
+
+		buf = append(buf, '?')
+	}
+
+	e.buf = buf
+
+	return nil
+}
+
+// appendRune appends the encoded rune to p, returning false if the rune cannot
+// be represented in the encoding.
+func appendRune(p []byte, enc Encoding, r rune) ([]byte, bool) {
+	switch enc {
+	case EncodingUTF16LE, EncodingUTF16BE:
+		order := binary.AppendByteOrder(binary.BigEndian)
+		if enc == EncodingUTF16LE {
+			order = binary.LittleEndian
+		}
+
+		if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+			return order.AppendUint16(order.AppendUint16(p, uint16(r1)), uint16(r2)), true
+		}
+
+		return order.AppendUint16(p, uint16(r)), true
+	case EncodingUTF32LE:
+		return binary.LittleEndian.AppendUint32(p, uint32(r)), true
+	case EncodingUTF32BE:
+		return binary.BigEndian.AppendUint32(p, uint32(r)), true
+	case EncodingLatin1:
+		if r > 0xFF {
+			return p, false
+		}
+
+		return append(p, byte(r)), true
+	case EncodingWindows1252:
+		if r < 0x80 || r >= 0xA0 && r <= 0xFF {
+			return append(p, byte(r)), true
+		}
+
+		for n, c := range windows1252 {
+			if c == r && c != utf8.RuneError {
+				return append(p, byte(0x80+n)), true
+			}
+		}
+
+		return p, false
+	}
+
+	return utf8.AppendRune(p, r), true
+}
+
+// Errors.
+var (
+	ErrInvalidSequence = errors.New("invalid byte sequence")
+	ErrUnencodable     = errors.New("rune cannot be represented in encoding")
+)
@@ -0,0 +1,148 @@
+package memio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+var (
+	_ io.RuneScanner  = new(Decoder)
+	_ io.Reader       = new(Decoder)
+	_ io.Writer       = new(Encoder)
+	_ io.StringWriter = new(Encoder)
+)
+
+func TestDecoder(t *testing.T) {
+	for n, test := range [...]struct {
+		Enc    Encoding
+		Input  string
+		Output string
+		Final  Encoding
+	}{
+		{EncodingUTF8, "\xef\xbb\xbfHello", "Hello", EncodingUTF8},
+		{EncodingUTF16LE, "\xff\xfeH\x00i\x00=\xd8\x00\xde", "Hi😀", EncodingUTF16LE},
+		{EncodingUTF16LE, "\xfe\xff\x00H\x00i\xd8=\xde\x00", "Hi😀", EncodingUTF16BE},
+		{EncodingUTF16BE, "\x00H\x00i", "Hi", EncodingUTF16BE},
+		{EncodingUTF8, "\x00\x00\xfe\xff\x00\x00\x00H\x00\x01\xf6\x00", "H😀", EncodingUTF32BE},
+		{EncodingUTF32LE, "H\x00\x00\x00\x00\xf6\x01\x00", "H😀", EncodingUTF32LE},
+		{EncodingLatin1, "caf\xe9 \xff\xfe", "café ÿþ", EncodingLatin1},
+		{EncodingWindows1252, "\x93quoted\x94 \x80", "“quoted” €", EncodingWindows1252},
+		{EncodingUTF16LE, "H\x00\x00\xd8i\x00", "H�i", EncodingUTF16LE},
+		{EncodingWindows1252, "a\x81b", "a�b", EncodingWindows1252},
+	} {
+		d := NewDecoder(iotest.OneByteReader(strings.NewReader(test.Input)), test.Enc)
+
+		if out, err := io.ReadAll(d); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if string(out) != test.Output {
+			t.Errorf("test %d: expecting %q, got %q", n+1, test.Output, out)
+		} else if enc := d.Encoding(); enc != test.Final {
+			t.Errorf("test %d: expecting encoding %d, got %d", n+1, test.Final, enc)
+		} else if off := d.Offset(); off != int64(len(test.Input)) {
+			t.Errorf("test %d: expecting offset %d, got %d", n+1, len(test.Input), off)
+		}
+	}
+}
+
+func TestDecoderRunes(t *testing.T) {
+	d := NewDecoder(strings.NewReader("\xff\xfeA\x00=\xd8\x00\xde\xe9\x00"), EncodingUTF16LE)
+
+	if p, err := d.Peek(5); err != nil || string(p) != "A😀" {
+		t.Errorf("expecting %q, nil, got %q, %v", "A😀", p, err)
+	} else if r, size, err := d.ReadRune(); r != 'A' || size != 2 || err != nil {
+		t.Errorf("expecting 'A', 2, nil, got %q, %d, %v", r, size, err)
+	} else if off := d.Offset(); off != 4 {
+		t.Errorf("expecting offset 4, got %d", off)
+	} else if r, size, err = d.ReadRune(); r != '😀' || size != 4 || err != nil {
+		t.Errorf("expecting '😀', 4, nil, got %q, %d, %v", r, size, err)
+	} else if err = d.UnreadRune(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if off := d.Offset(); off != 4 {
+		t.Errorf("expecting offset 4, got %d", off)
+	} else if err = d.UnreadRune(); err != ErrInvalidUnreadRune {
+		t.Errorf("expecting ErrInvalidUnreadRune, got %v", err)
+	}
+
+	buf := make([]byte, 2)
+
+	if n, err := d.Read(buf); n != 2 || err != nil || string(buf) != "\xf0\x9f" {
+		t.Errorf("expecting 2, nil, %q, got %d, %v, %q", "\xf0\x9f", n, err, buf)
+	} else if r, size, err := d.ReadRune(); r != utf8.RuneError || size != 0 || err != nil {
+		t.Errorf("expecting RuneError, 0, nil, got %q, %d, %v", r, size, err)
+	} else if n, err = d.Read(buf); n != 1 || err != nil || buf[0] != 0x80 {
+		t.Errorf("expecting 1, nil, 0x80, got %d, %v, %x", n, err, buf[:n])
+	} else if n, err = d.Read(buf); n != 2 || err != nil || string(buf) != "é" {
+		t.Errorf("expecting 2, nil, %q, got %d, %v, %q", "é", n, err, buf)
+	} else if _, _, err = d.ReadRune(); err != io.EOF {
+		t.Errorf("expecting io.EOF, got %v", err)
+	}
+}
+
+func TestStrictDecoder(t *testing.T) {
+	var opE *OpError
+
+	d := NewStrictDecoder(strings.NewReader("\xff\xfeH\x00i\x00\x00\xdcx\x00"), EncodingUTF16LE)
+
+	if out, err := io.ReadAll(d); string(out) != "Hi" {
+		t.Errorf("expecting %q, got %q", "Hi", out)
+	} else if !errors.As(err, &opE) {
+		t.Errorf("expecting *OpError, got %v", err)
+	} else if opE.Offset != 6 || opE.Err != ErrInvalidSequence {
+		t.Errorf("expecting ErrInvalidSequence at offset 6, got %v", opE)
+	} else if _, _, err = d.ReadRune(); !errors.Is(err, ErrInvalidSequence) {
+		t.Errorf("expecting ErrInvalidSequence, got %v", err)
+	} else if _, err = NewStrictDecoder(strings.NewReader("ab\x8fc"), EncodingWindows1252).Peek(4); !errors.As(err, &opE) || opE.Offset != 2 {
+		t.Errorf("expecting error at offset 2, got %v", err)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	const text = "Hi “😀” é"
+
+	for n, enc := range [...]Encoding{EncodingUTF8, EncodingUTF16LE, EncodingUTF16BE, EncodingUTF32LE, EncodingUTF32BE} {
+		var buf bytes.Buffer
+
+		e := NewEncoder(&buf, enc)
+
+		if err := e.WriteBOM(); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		}
+
+		for i := 0; i < len(text); i += 3 {
+			if _, err := e.Write([]byte(text[i:min(i+3, len(text))])); err != nil {
+				t.Errorf("test %d: unexpected error: %s", n+1, err)
+			}
+		}
+
+		if out, err := io.ReadAll(NewDecoder(&buf, EncodingUTF8)); err != nil {
+			t.Errorf("test %d: unexpected error: %s", n+1, err)
+		} else if string(out) != text {
+			t.Errorf("test %d: expecting %q, got %q", n+1, text, out)
+		}
+	}
+
+	var buf bytes.Buffer
+
+	if _, err := NewEncoder(&buf, EncodingWindows1252).WriteString(text); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	} else if out := buf.String(); out != "Hi \x93?\x94 \xe9" {
+		t.Errorf("expecting %q, got %q", "Hi \x93?\x94 \xe9", out)
+	}
+
+	buf.Reset()
+
+	var opE *OpError
+
+	if n, err := NewStrictEncoder(&buf, EncodingLatin1).WriteString("café “"); !errors.As(err, &opE) {
+		t.Errorf("expecting *OpError, got %v", err)
+	} else if n != 6 || opE.Offset != 6 || opE.Err != ErrUnencodable {
+		t.Errorf("expecting 6 bytes and ErrUnencodable at offset 6, got %d, %v", n, opE)
+	} else if out := buf.String(); out != "caf\xe9 " {
+		t.Errorf("expecting %q, got %q", "caf\xe9 ", out)
+	}
+}